@@ -0,0 +1,188 @@
+package dgkala_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mamal72/dgkala"
+	"github.com/mamal72/dgkala/dgkalatest"
+)
+
+func TestIncredibleOffers(t *testing.T) {
+	offer := dgkala.IncredibleOffer{
+		ID:             1,
+		ProductID:      6071,
+		Title:          "Offer",
+		ImagePaths:     dgkala.ImagePaths{Original: "original.jpg"},
+		ProductTitleFa: "کیف",
+		ProductTitleEn: "Case",
+		Discount:       dgkala.Rials(1000),
+		Price:          dgkala.Rials(10000),
+	}
+	tests := []struct {
+		name     string
+		options  []dgkalatest.Option
+		want     []dgkala.IncredibleOffer
+		wantType string
+		wantErr  bool
+	}{
+		{
+			name:     "Test should return a slice of incredible offers",
+			options:  []dgkalatest.Option{dgkalatest.WithOffers(offer)},
+			want:     []dgkala.IncredibleOffer{offer},
+			wantType: "[]dgkala.IncredibleOffer",
+			wantErr:  false,
+		},
+		{
+			name:     "Test should return an error when the server fails",
+			options:  []dgkalatest.Option{dgkalatest.WithError(dgkala.EndpointIncredibleOffers, http.StatusInternalServerError)},
+			wantType: "[]dgkala.IncredibleOffer",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := dgkalatest.NewServer(tt.options...)
+			defer server.Close()
+
+			got, err := server.Client().IncredibleOffers(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncredibleOffers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if reflect.TypeOf(got).String() != tt.wantType {
+				t.Errorf("type of IncredibleOffers() = %v, want type of %v", reflect.TypeOf(got), tt.wantType)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IncredibleOffers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	result := dgkala.ProductSearchResult{
+		ID:                 6071,
+		EnglishTitle:       "Case Logic DLBP",
+		PersianTitle:       "کوله پشتی",
+		Image:              "image.jpg",
+		ExistsStatus:       dgkala.Available,
+		IsActive:           true,
+		MinimumPrice:       dgkala.Rials(10000),
+		MaximumPrice:       dgkala.Rials(12000),
+		RegisteredDateTime: time.Date(2017, 5, 1, 12, 30, 0, 0, dgkala.TehranLocation()),
+		Colors:             []dgkala.ProductColor{{Title: "Black", Hex: "#000000", Code: "black"}},
+	}
+	server := dgkalatest.NewServer(dgkalatest.WithSearchResults(result))
+	defer server.Close()
+	client := server.Client()
+
+	tests := []struct {
+		name      string
+		keyword   string
+		wantCount int64
+	}{
+		{"Test should return matching products", "case logic", 1},
+		{"Test should not return other products", "phone", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Search(context.Background(), tt.keyword)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if got.Count != tt.wantCount || int64(len(got.Results)) != tt.wantCount {
+				t.Fatalf("Search() count = %v, results = %v, want %v", got.Count, len(got.Results), tt.wantCount)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			want := result
+			want.Image = server.URL + "/digikala/image.jpg"
+			if !reflect.DeepEqual(got.Results[0], want) {
+				t.Errorf("Search() result = %+v, want %+v", got.Results[0], want)
+			}
+		})
+	}
+}
+
+func TestGetProductByID(t *testing.T) {
+	product := dgkala.ProductByID{
+		ID:           6071,
+		EnglishTitle: "Case Logic DLBP",
+		PersianTitle: "کوله پشتی",
+		Strengths:    "Strong",
+		MinPrice:     dgkala.Rials(10000),
+	}
+	server := dgkalatest.NewServer(dgkalatest.WithProducts(product))
+	defer server.Close()
+	client := server.Client()
+
+	tests := []struct {
+		name         string
+		productID    dgkala.ProductID
+		want         dgkala.ProductByID
+		wantNotFound bool
+	}{
+		{"Test should return the product", 6071, product, false},
+		{"Test should return ErrNotFound for unknown products", 1, dgkala.ProductByID{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetProductByID(context.Background(), tt.productID)
+			if errors.Is(err, dgkala.ErrNotFound) != tt.wantNotFound {
+				t.Fatalf("GetProductByID() error = %v, wantNotFound %v", err, tt.wantNotFound)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetProductByID() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_rawJSON(t *testing.T) {
+	server := dgkalatest.NewServer(
+		dgkalatest.WithOffers(dgkala.IncredibleOffer{ID: 1, Raw: dgkala.RawJSON(`{"Badge":"hot"}`)}),
+		dgkalatest.WithProducts(dgkala.ProductByID{ID: 2, Raw: dgkala.RawJSON(`{"Badge":"new"}`)}),
+		dgkalatest.WithSearchResults(dgkala.ProductSearchResult{ID: 3, EnglishTitle: "Phone", Raw: dgkala.RawJSON(`{"Badge":"sale"}`)}),
+	)
+	defer server.Close()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		options []dgkala.Option
+		want    []string
+	}{
+		{"Test should not keep raw JSON by default", nil, []string{"", "", ""}},
+		{"Test should keep raw JSON if enabled", []dgkala.Option{dgkala.WithRawJSON(true)}, []string{"hot", "new", "sale"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := server.Client(tt.options...)
+			offers, err := client.IncredibleOffers(ctx)
+			if err != nil {
+				t.Fatalf("IncredibleOffers() error = %v", err)
+			}
+			product, err := client.GetProductByID(ctx, 2)
+			if err != nil {
+				t.Fatalf("GetProductByID() error = %v", err)
+			}
+			searchResult, err := client.Search(ctx, "phone")
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			for i, raw := range []dgkala.RawJSON{offers[0].Raw, product.Raw, searchResult.Results[0].Raw} {
+				got, _ := raw.GetString("Badge")
+				if got != tt.want[i] {
+					t.Errorf("Raw.GetString(Badge) %d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
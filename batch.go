@@ -0,0 +1,68 @@
+package dgkala
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchWorkers is the number of concurrent requests of batch calls if BatchOptions.Workers isn't set
+const DefaultBatchWorkers = 4
+
+// BatchOptions configures batch calls
+type BatchOptions struct {
+	// Workers is the maximum number of concurrent requests
+	Workers int
+}
+
+// ProductResult is the result of getting a product in a batch
+type ProductResult struct {
+	ID      ProductID
+	Product ProductByID
+	Err     error
+}
+
+// GetProductsByID gets products concurrently and returns their results in the order of ids.
+// Failed products have their Err set and don't affect the others.
+// If ctx is canceled, the products which are not fetched yet fail with the context error.
+func (c *Client) GetProductsByID(ctx context.Context, ids []ProductID, options BatchOptions) []ProductResult {
+	workers := options.Workers
+	if workers < 1 {
+		workers = DefaultBatchWorkers
+	}
+	if workers > len(ids) {
+		workers = len(ids)
+	}
+
+	results := make([]ProductResult, len(ids))
+	for i, ID := range ids {
+		results[i].ID = ID
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].Product, results[i].Err = c.GetProductByID(ctx, ids[i])
+			}
+		}()
+	}
+
+feed:
+	for i := range ids {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			for ; i < len(ids); i++ {
+				results[i].Err = ctx.Err()
+			}
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
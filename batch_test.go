@@ -0,0 +1,93 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_GetProductsByID(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		ID := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if ID == "404" {
+			w.Write([]byte(`{"Data":null,"Status":"Ok"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"Data":{"ProductId":%s},"Status":"Ok"}`, ID)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithService2BaseURL(server.URL))
+	ids := []ProductID{5, 3, 404, 1, 8, 13, 2}
+	got := client.GetProductsByID(context.Background(), ids, BatchOptions{Workers: 3})
+
+	if len(got) != len(ids) {
+		t.Fatalf("GetProductsByID() returned %v results, want %v", len(got), len(ids))
+	}
+	for i, result := range got {
+		if result.ID != ids[i] {
+			t.Errorf("GetProductsByID() result %v ID = %v, want %v", i, result.ID, ids[i])
+		}
+		if ids[i] == 404 {
+			if !errors.Is(result.Err, ErrNotFound) {
+				t.Errorf("GetProductsByID() result %v error = %v, want %v", i, result.Err, ErrNotFound)
+			}
+			continue
+		}
+		if result.Err != nil || result.Product.ID != ids[i] {
+			t.Errorf("GetProductsByID() result %v = %+v, want product %v", i, result, ids[i])
+		}
+	}
+	if maxRunning > 3 {
+		t.Errorf("GetProductsByID() sent %v concurrent requests, want at most 3", maxRunning)
+	}
+}
+
+func TestClient_GetProductsByIDCancel(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	client := NewClient(WithService2BaseURL(server.URL))
+	got := client.GetProductsByID(ctx, []ProductID{1, 2, 3, 4, 5, 6}, BatchOptions{Workers: 2})
+
+	for i, result := range got {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("GetProductsByID() result %v error = %v, want %v", i, result.Err, context.Canceled)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests > 2 {
+		t.Errorf("GetProductsByID() sent %v requests, want at most 2", requests)
+	}
+}
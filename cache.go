@@ -0,0 +1,298 @@
+package dgkala
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Endpoint identifies a DGKala API endpoint
+type Endpoint int
+
+const (
+	// EndpointIncredibleOffers is the incredible offers endpoint
+	EndpointIncredibleOffers Endpoint = iota
+	// EndpointSearch is the products search endpoint
+	EndpointSearch
+	// EndpointProductByID is the product details endpoint
+	EndpointProductByID
+	// EndpointProductComments is the product comments endpoint
+	EndpointProductComments
+	// EndpointProductQuestions is the product questions and answers endpoint
+	EndpointProductQuestions
+	// EndpointCategories is the category tree endpoint
+	EndpointCategories
+	// EndpointSuggest is the search autocomplete endpoint
+	EndpointSuggest
+)
+
+func (e Endpoint) String() string {
+	switch e {
+	case EndpointIncredibleOffers:
+		return "incredible-offers"
+	case EndpointSearch:
+		return "search"
+	case EndpointProductByID:
+		return "product-by-id"
+	case EndpointProductComments:
+		return "product-comments"
+	case EndpointProductQuestions:
+		return "product-questions"
+	case EndpointCategories:
+		return "categories"
+	case EndpointSuggest:
+		return "suggest"
+	}
+	return fmt.Sprintf("Endpoint(%d)", int(e))
+}
+
+// host returns the host serving the endpoint
+func (e Endpoint) host() Host {
+	if e == EndpointSearch || e == EndpointSuggest {
+		return HostSearch
+	}
+	return HostService2
+}
+
+// defaultCacheTTLs are the time to live of cached responses of each endpoint
+var defaultCacheTTLs = map[Endpoint]time.Duration{
+	EndpointIncredibleOffers: time.Minute,
+	EndpointSearch:           5 * time.Minute,
+	EndpointProductByID:      time.Hour,
+	EndpointProductComments:  10 * time.Minute,
+	EndpointProductQuestions: 10 * time.Minute,
+	EndpointCategories:       24 * time.Hour,
+	EndpointSuggest:          10 * time.Minute,
+}
+
+// Cache stores API response bodies.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key if it's not expired
+	Get(key string) ([]byte, bool)
+	// Set stores a value for key which expires after ttl
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CacheStats contains the hit and miss counts of a client cache
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// WithCache sets the cache used to store successful responses.
+// Responses are not cached by default.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithCacheTTL sets how long responses of an endpoint are cached. Zero disables caching of the endpoint.
+func WithCacheTTL(endpoint Endpoint, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cacheTTLs[endpoint] = ttl
+	}
+}
+
+// CacheStats returns the hit and miss counts of the client cache
+func (c *Client) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadInt64(&c.cacheHits),
+		Misses: atomic.LoadInt64(&c.cacheMisses),
+	}
+}
+
+// cacheKey derives a cache key from a request address and its headers
+func (c *Client) cacheKey(address string, headers requestHeader) string {
+	all := map[string]string{}
+	for key, value := range c.headers {
+		all[key] = value
+	}
+	for key, value := range headers {
+		all[key] = value
+	}
+	if c.userAgent != "" {
+		all["User-Agent"] = c.userAgent
+	}
+	keys := make([]string, 0, len(all))
+	for key := range all {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	builder.WriteString("GET " + address + "\n")
+	for _, key := range keys {
+		builder.WriteString(key + ": " + all[key] + "\n")
+	}
+	sum := sha256.Sum256([]byte(builder.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// lru is a size bounded least recently used map with expiring entries
+type lru[V any] struct {
+	mu      sync.Mutex
+	size    int
+	items   map[string]*list.Element
+	order   *list.List
+	expired func(expires time.Time) bool
+}
+
+type lruEntry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+func newLRU[V any](size int, now func() time.Time) *lru[V] {
+	if size < 1 {
+		size = 1
+	}
+	return &lru[V]{
+		size:  size,
+		items: map[string]*list.Element{},
+		order: list.New(),
+		expired: func(expires time.Time) bool {
+			return !now().Before(expires)
+		},
+	}
+}
+
+func (l *lru[V]) get(key string) (V, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var zero V
+	element, ok := l.items[key]
+	if !ok {
+		return zero, false
+	}
+	entry := element.Value.(*lruEntry[V])
+	if l.expired(entry.expires) {
+		l.order.Remove(element)
+		delete(l.items, key)
+		return zero, false
+	}
+	l.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (l *lru[V]) set(key string, value V, expires time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.items[key]; ok {
+		entry := element.Value.(*lruEntry[V])
+		entry.value, entry.expires = value, expires
+		l.order.MoveToFront(element)
+		return
+	}
+	l.items[key] = l.order.PushFront(&lruEntry[V]{key, value, expires})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry[V]).key)
+	}
+}
+
+func (l *lru[V]) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// LRUCache is an in-memory Cache which evicts the least recently used entries
+type LRUCache struct {
+	entries *lru[[]byte]
+	now     func() time.Time
+}
+
+// NewLRUCache returns an LRUCache holding at most size entries
+func NewLRUCache(size int) *LRUCache {
+	cache := &LRUCache{now: time.Now}
+	cache.entries = newLRU[[]byte](size, func() time.Time { return cache.now() })
+	return cache
+}
+
+// Get returns the value stored for key if it's not expired
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	return c.entries.get(key)
+}
+
+// Set stores a value for key which expires after ttl
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	stored := make([]byte, len(value))
+	copy(stored, value)
+	c.entries.set(key, stored, c.now().Add(ttl))
+}
+
+// Len returns the number of entries in the cache
+func (c *LRUCache) Len() int {
+	return c.entries.len()
+}
+
+// FileCache is a Cache storing entries as files in a directory
+type FileCache struct {
+	dir string
+	now func() time.Time
+}
+
+// NewFileCache returns a FileCache storing entries in dir, creating it if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir, now: time.Now}, nil
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the value stored for key if it's not expired
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	content, err := ioutil.ReadFile(path)
+	if err != nil || len(content) < 8 {
+		return nil, false
+	}
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(content[:8])))
+	if !c.now().Before(expires) {
+		os.Remove(path)
+		return nil, false
+	}
+	return content[8:], true
+}
+
+// Set stores a value for key which expires after ttl.
+// Entries are written atomically, so concurrent readers never see partial files.
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	file, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint64(header, uint64(c.now().Add(ttl).UnixNano()))
+	_, err = file.Write(append(header, value...))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+}
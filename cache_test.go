@@ -0,0 +1,179 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewLRUCache(2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Second)
+	cache.Get("a")
+	cache.Set("c", []byte("3"), time.Minute)
+	now = now.Add(2 * time.Second)
+
+	tests := []struct {
+		name   string
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"Test should return recently used entries", "a", "1", true},
+		{"Test should evict least recently used entries", "b", "", false},
+		{"Test should return new entries", "c", "3", true},
+		{"Test should not return missing entries", "d", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cache.Get(tt.key)
+			if ok != tt.wantOK || string(got) != tt.want {
+				t.Errorf("LRUCache.Get() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("LRUCache.Get() returned an expired entry")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("LRUCache.Len() = %v, want 1", cache.Len())
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	cache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+	cache.now = func() time.Time { return now }
+
+	cache.Set("key", []byte("value"), time.Minute)
+	if got, ok := cache.Get("key"); !ok || string(got) != "value" {
+		t.Errorf("FileCache.Get() = %q, %v, want %q, true", got, ok, "value")
+	}
+	if _, ok := cache.Get("missing"); ok {
+		t.Errorf("FileCache.Get() returned a missing entry")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("key"); ok {
+		t.Errorf("FileCache.Get() returned an expired entry")
+	}
+}
+
+func TestClient_cache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/api/search":
+			w.Write([]byte(`{"took":1,"hits":{"total":0,"hits":[]}}`))
+		default:
+			w.Write([]byte(`{"Data":[],"Status":"Ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithService2BaseURL(server.URL),
+		WithSearchBaseURL(server.URL),
+		WithCache(NewLRUCache(10)),
+		WithCacheTTL(EndpointIncredibleOffers, 0),
+	)
+	ctx := context.Background()
+	for _, keyword := range []string{"a", "a", "b", "a"} {
+		if _, err := client.Search(ctx, keyword); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.IncredibleOffers(ctx); err != nil {
+			t.Fatalf("IncredibleOffers() error = %v", err)
+		}
+	}
+
+	if requests != 4 {
+		t.Errorf("requests = %v, want 4", requests)
+	}
+	want := CacheStats{Hits: 2, Misses: 2}
+	if got := client.CacheStats(); got != want {
+		t.Errorf("CacheStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_cacheFailures(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case strings.Contains(r.URL.Path, "/6071"):
+			w.Write([]byte(`{"Data":null,"Status":"Ok"}`))
+		case r.URL.Path == "/api/search":
+			w.Write([]byte(`{"took":1,"hits":`))
+		default:
+			w.Write([]byte(`{"Data":null,"Status":"Error"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithService2BaseURL(server.URL),
+		WithSearchBaseURL(server.URL),
+		WithCache(NewLRUCache(10)),
+	)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetProductByID(ctx, 6071); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetProductByID() error = %v, want %v", err, ErrNotFound)
+		}
+		var apiErr *APIError
+		if _, err := client.IncredibleOffers(ctx); !errors.As(err, &apiErr) {
+			t.Errorf("IncredibleOffers() error = %v, want an APIError", err)
+		}
+		var decodeErr *DecodeError
+		if _, err := client.Search(ctx, "a"); !errors.As(err, &decodeErr) {
+			t.Errorf("Search() error = %v, want a DecodeError", err)
+		}
+	}
+
+	if requests != 6 {
+		t.Errorf("requests = %v, want 6", requests)
+	}
+	want := CacheStats{Hits: 0, Misses: 6}
+	if got := client.CacheStats(); got != want {
+		t.Errorf("CacheStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_cacheKey(t *testing.T) {
+	client := NewClient()
+	other := NewClient(WithUserAgent("other"))
+	key := client.cacheKey("http://a", requestHeader{"A": "1"})
+	tests := []struct {
+		name string
+		got  string
+		same bool
+	}{
+		{"Test should derive the same key for the same request", client.cacheKey("http://a", requestHeader{"A": "1"}), true},
+		{"Test should derive different keys for different URLs", client.cacheKey("http://b", requestHeader{"A": "1"}), false},
+		{"Test should derive different keys for different headers", client.cacheKey("http://a", requestHeader{"A": "2"}), false},
+		{"Test should derive different keys for different user agents", other.cacheKey("http://a", requestHeader{"A": "1"}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.got == key) != tt.same {
+				t.Errorf("cacheKey() = %v, key = %v, want same %v", tt.got, key, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package dgkala
+
+import (
+	"context"
+	"iter"
+)
+
+// Category is a DGKala product category with its subcategories
+type Category struct {
+	ID CategoryID `json:"Id"`
+	// ParentID is the ID of the parent category, zero for root categories
+	ParentID     CategoryID `json:"ParentId"`
+	PersianTitle string     `json:"FaTitle"`
+	EnglishTitle string     `json:"EnTitle"`
+	URLCode      string     `json:"UrlCode"`
+	// Children are the subcategories of the category. The API returns a flat list of categories
+	// which Client.Categories builds the tree from, so they are only set in its results.
+	Children []Category `json:",omitempty"`
+}
+
+func (c *Client) getCategoriesAPIAddress() string {
+	return c.service2BaseURL + categoriesAPIPath
+}
+
+// Categories returns the DGKala category tree as a slice of root categories
+func (c *Client) Categories(ctx context.Context) ([]Category, error) {
+	return coalesce(c, ctx, EndpointCategories, c.getCategoriesAPIAddress(), c.categories)
+}
+
+func (c *Client) categories(ctx context.Context) ([]Category, error) {
+	headers := getRequestHeaders()
+	apiAddress := c.getCategoriesAPIAddress()
+
+	var categories []Category
+	err := c.get(ctx, EndpointCategories, apiAddress, headers, func(body []byte) error {
+		return decodeService2Response(body, apiAddress, &categories)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// buildCategoryTree builds a tree from a flat list of categories.
+// Categories with unknown parents are considered root categories
+// and so is the first category found in each cycle of parent IDs.
+func buildCategoryTree(categories []Category) []Category {
+	byID := map[CategoryID]Category{}
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	// roots are kept apart from children, as 0 is both the ParentID of roots and a valid ID
+	topLevel := []Category{}
+	children := map[CategoryID][]Category{}
+	for _, category := range categories {
+		parentID := category.ParentID
+		if _, ok := byID[parentID]; !ok || parentID == 0 || parentID == category.ID {
+			topLevel = append(topLevel, category)
+			continue
+		}
+		children[parentID] = append(children[parentID], category)
+	}
+
+	visited := map[CategoryID]bool{}
+	var build func(siblings []Category) []Category
+	build = func(siblings []Category) []Category {
+		nodes := []Category{}
+		for _, category := range siblings {
+			if visited[category.ID] {
+				continue
+			}
+			visited[category.ID] = true
+			category.Children = build(children[category.ID])
+			nodes = append(nodes, category)
+		}
+		return nodes
+	}
+	roots := build(topLevel)
+
+	// categories which are not visited yet are in or under a cycle,
+	// so the category of the cycle their parents lead to becomes a root
+	for _, category := range categories {
+		if visited[category.ID] {
+			continue
+		}
+		path := map[CategoryID]bool{}
+		for !path[category.ID] {
+			path[category.ID] = true
+			category = byID[category.ParentID]
+		}
+		visited[category.ID] = true
+		category.Children = build(children[category.ID])
+		roots = append(roots, category)
+	}
+	return roots
+}
+
+// CategoryProducts returns a page of the products of a category
+func (c *Client) CategoryProducts(ctx context.Context, categoryID CategoryID, options SearchOptions) (SearchResult, error) {
+	return c.SearchQuery(ctx, NewSearchQuery("").Category(categoryID), options)
+}
+
+// CategoryProductsAll returns an iterator over all the products of a category, like SearchAll
+func (c *Client) CategoryProductsAll(ctx context.Context, categoryID CategoryID, options SearchOptions) iter.Seq2[ProductSearchResult, error] {
+	return c.SearchQueryAll(ctx, NewSearchQuery("").Category(categoryID), options)
+}
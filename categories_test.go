@@ -0,0 +1,105 @@
+package dgkala
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_buildCategoryTree(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []Category
+		want       []Category
+	}{
+		{
+			name: "Test should build nested categories in order",
+			categories: []Category{
+				{ID: 1, EnglishTitle: "Digital"},
+				{ID: 2, ParentID: 1, EnglishTitle: "Mobile"},
+				{ID: 3, ParentID: 2, EnglishTitle: "Phones"},
+				{ID: 4, ParentID: 1, EnglishTitle: "Laptop"},
+				{ID: 5, EnglishTitle: "Books"},
+			},
+			want: []Category{
+				{ID: 1, EnglishTitle: "Digital", Children: []Category{
+					{ID: 2, ParentID: 1, EnglishTitle: "Mobile", Children: []Category{
+						{ID: 3, ParentID: 2, EnglishTitle: "Phones", Children: []Category{}},
+					}},
+					{ID: 4, ParentID: 1, EnglishTitle: "Laptop", Children: []Category{}},
+				}},
+				{ID: 5, EnglishTitle: "Books", Children: []Category{}},
+			},
+		},
+		{
+			name: "Test should consider orphans and self parents roots",
+			categories: []Category{
+				{ID: 1, ParentID: 99},
+				{ID: 2, ParentID: 2},
+			},
+			want: []Category{
+				{ID: 1, ParentID: 99, Children: []Category{}},
+				{ID: 2, ParentID: 2, Children: []Category{}},
+			},
+		},
+		{
+			name: "Test should make a category of each parent cycle a root",
+			categories: []Category{
+				{ID: 3, ParentID: 1},
+				{ID: 1, ParentID: 2},
+				{ID: 2, ParentID: 1},
+				{ID: 4},
+			},
+			want: []Category{
+				{ID: 4, Children: []Category{}},
+				{ID: 1, ParentID: 2, Children: []Category{
+					{ID: 3, ParentID: 1, Children: []Category{}},
+					{ID: 2, ParentID: 1, Children: []Category{}},
+				}},
+			},
+		},
+		{
+			name: "Test should keep a category with ID 0 a root",
+			categories: []Category{
+				{ID: 0},
+				{ID: 1},
+				{ID: 2},
+				{ID: 3, ParentID: 1},
+			},
+			want: []Category{
+				{ID: 0, Children: []Category{}},
+				{ID: 1, Children: []Category{
+					{ID: 3, ParentID: 1, Children: []Category{}},
+				}},
+				{ID: 2, Children: []Category{}},
+			},
+		},
+		{
+			name:       "Test should return an empty tree for no categories",
+			categories: nil,
+			want:       []Category{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildCategoryTree(tt.categories); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildCategoryTree() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategory_JSON(t *testing.T) {
+	tree := buildCategoryTree([]Category{{ID: 1}, {ID: 2, ParentID: 1}})
+	encoded, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded []Category
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(decoded) != 1 || len(decoded[0].Children) != 1 || decoded[0].Children[0].ID != 2 {
+		t.Errorf("Unmarshal(Marshal()) = %+v, want the category tree", decoded)
+	}
+}
@@ -0,0 +1,230 @@
+package dgkala
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultService2BaseURL = "https://service2.digikala.com"
+	defaultSearchBaseURL   = "https://search.digikala.com"
+	defaultFileBaseURL     = "https://file.digikala.com"
+)
+
+// Client is a DGKala API client.
+// A Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	httpClient      *http.Client
+	service2BaseURL string
+	searchBaseURL   string
+	fileBaseURL     string
+	headers         requestHeader
+	userAgent       string
+	retryPolicy     RetryPolicy
+	rateLimits      map[Host]RateLimit
+	limiters        map[Host]*tokenBucket
+	cache           Cache
+	cacheTTLs       map[Endpoint]time.Duration
+	cacheHits       int64
+	cacheMisses     int64
+	decodeMode      DecodeMode
+	rawJSON         bool
+	coalescing      bool
+	flights         *flightGroup
+
+	normalizeKeywords bool
+
+	suggestCacheSize int
+	suggestCacheTTL  time.Duration
+	suggestCache     *lru[Suggestions]
+
+	// now, sleep and random are replaced in tests to make them deterministic
+	now    func() time.Time
+	sleep  func(ctx context.Context, duration time.Duration) error
+	random func() float64
+}
+
+// Option is a functional option for configuring a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to send requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithService2BaseURL sets the base URL of the service2 API host
+// which serves incredible offers and product details
+func WithService2BaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.service2BaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithSearchBaseURL sets the base URL of the search API host
+func WithSearchBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.searchBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithFileBaseURL sets the base URL of the static files host
+func WithFileBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.fileBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHeader adds a header which is sent with every request
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers[key] = value
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// NewClient returns a new Client configured with the given options
+func NewClient(options ...Option) *Client {
+	c := &Client{
+		httpClient:      http.DefaultClient,
+		service2BaseURL: defaultService2BaseURL,
+		searchBaseURL:   defaultSearchBaseURL,
+		fileBaseURL:     defaultFileBaseURL,
+		headers:         requestHeader{},
+		rateLimits:      map[Host]RateLimit{},
+		limiters:        map[Host]*tokenBucket{},
+		cacheTTLs:       map[Endpoint]time.Duration{},
+		now:             time.Now,
+		sleep:           sleepContext,
+		random:          rand.Float64,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+	for endpoint, ttl := range defaultCacheTTLs {
+		if _, ok := c.cacheTTLs[endpoint]; !ok {
+			c.cacheTTLs[endpoint] = ttl
+		}
+	}
+	if c.coalescing {
+		c.flights = newFlightGroup()
+	}
+	if c.suggestCacheSize > 0 && c.suggestCacheTTL > 0 {
+		c.suggestCache = newLRU[Suggestions](c.suggestCacheSize, func() time.Time { return c.now() })
+	}
+	for host, limit := range c.rateLimits {
+		if limit.Rate > 0 {
+			c.limiters[host] = newTokenBucket(limit)
+		}
+	}
+	return c
+}
+
+var defaultClient = NewClient()
+
+func (c *Client) sendRequest(ctx context.Context, address string, headers requestHeader) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range c.headers {
+		request.Header.Set(key, value)
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	if c.userAgent != "" {
+		request.Header.Set("User-Agent", c.userAgent)
+	}
+
+	return c.httpClient.Do(request)
+}
+
+// get sends a GET request to an endpoint and decodes the response body using decode.
+// The body is read from the client cache if it's available there.
+// Fetched bodies are only cached if decode accepts them, so API errors and malformed responses are not cached.
+func (c *Client) get(ctx context.Context, endpoint Endpoint, address string, headers requestHeader, decode func(body []byte) error) error {
+	ttl := c.cacheTTLs[endpoint]
+	if c.cache == nil || ttl <= 0 {
+		body, err := c.fetch(ctx, endpoint.host(), address, headers)
+		if err != nil {
+			return err
+		}
+		return decode(body)
+	}
+
+	key := c.cacheKey(address, headers)
+	if body, ok := c.cache.Get(key); ok {
+		atomic.AddInt64(&c.cacheHits, 1)
+		return decode(body)
+	}
+	atomic.AddInt64(&c.cacheMisses, 1)
+
+	body, err := c.fetch(ctx, endpoint.host(), address, headers)
+	if err != nil {
+		return err
+	}
+	if err := decode(body); err != nil {
+		return err
+	}
+	c.cache.Set(key, body, ttl)
+	return nil
+}
+
+// fetch sends a GET request and returns the response body.
+// Every attempt waits for the rate limiter of the host
+// and failed attempts are retried according to the client retry policy.
+func (c *Client) fetch(ctx context.Context, host Host, address string, headers requestHeader) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		if err := c.waitRateLimit(ctx, host); err != nil {
+			return nil, err
+		}
+		body, err := c.getOnce(ctx, address, headers)
+		if err == nil || !c.retryPolicy.shouldRetry(attempt, err) {
+			return body, err
+		}
+		delay := c.retryPolicy.delay(attempt, err, c.random)
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// getOnce sends a single GET request and returns the response body.
+// Non 2xx responses are returned as *HTTPError.
+func (c *Client) getOnce(ctx context.Context, address string, headers requestHeader) ([]byte, error) {
+	response, err := c.sendRequest(ctx, address, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		snippet, _ := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+		return nil, &HTTPError{
+			StatusCode: response.StatusCode,
+			URL:        address,
+			Body:       string(snippet),
+			RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), c.now()),
+		}
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
@@ -0,0 +1,149 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	httpClient := &http.Client{}
+	tests := []struct {
+		name    string
+		options []Option
+		want    Client
+	}{
+		{
+			name: "Test should use default values without options",
+			want: Client{
+				httpClient:      http.DefaultClient,
+				service2BaseURL: defaultService2BaseURL,
+				searchBaseURL:   defaultSearchBaseURL,
+				fileBaseURL:     defaultFileBaseURL,
+			},
+		},
+		{
+			name: "Test should apply options and trim trailing slashes",
+			options: []Option{
+				WithHTTPClient(httpClient),
+				WithService2BaseURL("http://service2.local/"),
+				WithSearchBaseURL("http://search.local"),
+				WithFileBaseURL("http://file.local/"),
+				WithUserAgent("dgkala-test"),
+			},
+			want: Client{
+				httpClient:      httpClient,
+				service2BaseURL: "http://service2.local",
+				searchBaseURL:   "http://search.local",
+				fileBaseURL:     "http://file.local",
+				userAgent:       "dgkala-test",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewClient(tt.options...)
+			if got.httpClient != tt.want.httpClient {
+				t.Errorf("NewClient().httpClient = %v, want %v", got.httpClient, tt.want.httpClient)
+			}
+			if got.service2BaseURL != tt.want.service2BaseURL {
+				t.Errorf("NewClient().service2BaseURL = %v, want %v", got.service2BaseURL, tt.want.service2BaseURL)
+			}
+			if got.searchBaseURL != tt.want.searchBaseURL {
+				t.Errorf("NewClient().searchBaseURL = %v, want %v", got.searchBaseURL, tt.want.searchBaseURL)
+			}
+			if got.fileBaseURL != tt.want.fileBaseURL {
+				t.Errorf("NewClient().fileBaseURL = %v, want %v", got.fileBaseURL, tt.want.fileBaseURL)
+			}
+			if got.userAgent != tt.want.userAgent {
+				t.Errorf("NewClient().userAgent = %v, want %v", got.userAgent, tt.want.userAgent)
+			}
+		})
+	}
+}
+
+func TestClient_sendRequestHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithHeader("X-Default", "default"),
+		WithHeader("ApplicationVersion", "0.0.0"),
+		WithUserAgent("dgkala-test"),
+	)
+	response, err := client.sendRequest(context.Background(), server.URL, getRequestHeaders())
+	if err != nil {
+		t.Fatalf("sendRequest() error = %v", err)
+	}
+	response.Body.Close()
+
+	want := map[string]string{
+		"X-Default":          "default",
+		"Applicationversion": "1.4.1",
+		"User-Agent":         "dgkala-test",
+	}
+	for key, value := range want {
+		if got.Get(key) != value {
+			t.Errorf("sendRequest() header %s = %v, want %v", key, got.Get(key), value)
+		}
+	}
+}
+
+func TestClient_contextCancellation(t *testing.T) {
+	released := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-released:
+		}
+	}))
+	defer server.Close()
+	defer close(released)
+
+	client := NewClient(
+		WithService2BaseURL(server.URL),
+		WithSearchBaseURL(server.URL),
+	)
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{
+			name: "Test should abort IncredibleOffers",
+			call: func(ctx context.Context) error {
+				_, err := client.IncredibleOffers(ctx)
+				return err
+			},
+		},
+		{
+			name: "Test should abort Search",
+			call: func(ctx context.Context) error {
+				_, err := client.Search(ctx, "keyword")
+				return err
+			},
+		},
+		{
+			name: "Test should abort GetProductByID",
+			call: func(ctx context.Context) error {
+				_, err := client.GetProductByID(ctx, 6071)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+			err := tt.call(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("error = %v, want %v", err, context.Canceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package dgkala
+
+import (
+	"context"
+	"sync"
+)
+
+// CoalescingStats contains the metrics of request coalescing
+type CoalescingStats struct {
+	// Calls is the number of calls which were executed
+	Calls int64
+	// Coalesced is the number of calls which shared the result of an identical in-flight call
+	Coalesced int64
+}
+
+// WithCoalescing enables or disables coalescing of identical in-flight calls.
+// Coalescing is disabled by default. When it's enabled, concurrent calls to the same endpoint with the same parameters
+// send a single request and share its decoded result, so the results must not be modified.
+func WithCoalescing(enabled bool) Option {
+	return func(c *Client) {
+		c.coalescing = enabled
+	}
+}
+
+// CoalescingStats returns the metrics of request coalescing
+func (c *Client) CoalescingStats() CoalescingStats {
+	if c.flights == nil {
+		return CoalescingStats{}
+	}
+	c.flights.mu.Lock()
+	defer c.flights.mu.Unlock()
+	return c.flights.stats
+}
+
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+	stats CoalescingStats
+}
+
+type flightCall struct {
+	done    chan struct{}
+	value   any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: map[string]*flightCall{}}
+}
+
+// coalesce calls fn once for all the concurrent calls with the same endpoint and address.
+// fn runs with a context which is canceled only when every waiting caller has given up,
+// so canceling the first caller doesn't fail the others.
+func coalesce[T any](c *Client, ctx context.Context, endpoint Endpoint, address string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if c.flights == nil {
+		return fn(ctx)
+	}
+
+	key := endpoint.String() + " " + address
+	group := c.flights
+	group.mu.Lock()
+	call, ok := group.calls[key]
+	if ok {
+		group.stats.Coalesced++
+	} else {
+		group.stats.Calls++
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		group.calls[key] = call
+		go func() {
+			value, err := fn(callCtx)
+			cancel()
+			group.mu.Lock()
+			call.value, call.err = value, err
+			if group.calls[key] == call {
+				delete(group.calls, key)
+			}
+			group.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	group.mu.Unlock()
+
+	select {
+	case <-call.done:
+		value, _ := call.value.(T)
+		return value, call.err
+	case <-ctx.Done():
+		group.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// no one is waiting for the result, so new calls shouldn't join this one
+			call.cancel()
+			if group.calls[key] == call {
+				delete(group.calls, key)
+			}
+		}
+		group.mu.Unlock()
+		var zero T
+		return zero, ctx.Err()
+	}
+}
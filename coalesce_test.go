@@ -0,0 +1,144 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBlockingServer returns a server which responds with body after release is closed
+func newBlockingServer(body string, release chan struct{}, requests *int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(requests, 1)
+		select {
+		case <-release:
+			w.Write([]byte(body))
+		case <-r.Context().Done():
+		}
+	}))
+}
+
+// waitForCoalesced waits until n calls of a client are coalesced
+func waitForCoalesced(t *testing.T, client *Client, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for client.CoalescingStats().Coalesced < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("CoalescingStats().Coalesced = %v, want %v", client.CoalescingStats().Coalesced, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestClient_coalescing(t *testing.T) {
+	var requests int64
+	release := make(chan struct{})
+	server := newBlockingServer(`{"Data":{"ProductId":6071,"Status":"marketable"},"Status":"Ok"}`, release, &requests)
+	defer server.Close()
+
+	client := NewClient(WithService2BaseURL(server.URL), WithCoalescing(true))
+	products := make([]ProductByID, 5)
+	errs := make([]error, 5)
+	var wg sync.WaitGroup
+	for i := range products {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			products[i], errs[i] = client.GetProductByID(context.Background(), 6071)
+		}()
+	}
+	waitForCoalesced(t, client, 4)
+	close(release)
+	wg.Wait()
+
+	for i := range products {
+		if errs[i] != nil || products[i].ID != 6071 {
+			t.Errorf("GetProductByID() = %v, %v, want product 6071", products[i].ID, errs[i])
+		}
+	}
+	if requests := atomic.LoadInt64(&requests); requests != 1 {
+		t.Errorf("sent %v requests, want 1", requests)
+	}
+	want := CoalescingStats{Calls: 1, Coalesced: 4}
+	if got := client.CoalescingStats(); got != want {
+		t.Errorf("CoalescingStats() = %+v, want %+v", got, want)
+	}
+
+	// finished calls are not shared
+	if _, err := client.GetProductByID(context.Background(), 6071); err != nil {
+		t.Errorf("GetProductByID() error = %v", err)
+	}
+	if requests := atomic.LoadInt64(&requests); requests != 2 {
+		t.Errorf("sent %v requests, want 2", requests)
+	}
+}
+
+func TestClient_coalescingCancel(t *testing.T) {
+	var requests int64
+	release := make(chan struct{})
+	server := newBlockingServer(`{"Data":[],"Status":"Ok"}`, release, &requests)
+	defer server.Close()
+
+	client := NewClient(WithService2BaseURL(server.URL), WithCoalescing(true))
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := client.IncredibleOffers(firstCtx)
+		firstErr <- err
+	}()
+	for atomic.LoadInt64(&requests) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := client.IncredibleOffers(context.Background())
+		secondErr <- err
+	}()
+	waitForCoalesced(t, client, 1)
+
+	// canceling the first caller doesn't fail the second one
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("IncredibleOffers() error = %v, want %v", err, context.Canceled)
+	}
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("IncredibleOffers() error = %v, want nil", err)
+	}
+	if requests := atomic.LoadInt64(&requests); requests != 1 {
+		t.Errorf("sent %v requests, want 1", requests)
+	}
+}
+
+func TestClient_coalescingDisabledByDefault(t *testing.T) {
+	var requests int64
+	release := make(chan struct{})
+	server := newBlockingServer(`{"Data":[],"Status":"Ok"}`, release, &requests)
+	defer server.Close()
+
+	client := NewClient(WithService2BaseURL(server.URL))
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.IncredibleOffers(context.Background()); err != nil {
+				t.Errorf("IncredibleOffers() error = %v", err)
+			}
+		}()
+	}
+	for atomic.LoadInt64(&requests) < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := client.CoalescingStats(); got != (CoalescingStats{}) {
+		t.Errorf("CoalescingStats() = %+v, want zero", got)
+	}
+}
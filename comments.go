@@ -0,0 +1,102 @@
+package dgkala
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// CommentSort is the sort order of product comments
+type CommentSort int
+
+const (
+	// CommentSortNewest sorts comments by date, newest first
+	CommentSortNewest CommentSort = iota
+	// CommentSortMostHelpful sorts comments by helpful votes, most helpful first
+	CommentSortMostHelpful
+	// CommentSortHighestRating sorts comments by rating, highest first
+	CommentSortHighestRating
+	// CommentSortLowestRating sorts comments by rating, lowest first
+	CommentSortLowestRating
+)
+
+// commentSortParameters are the comments API sort parameter values of each sort order
+var commentSortParameters = map[CommentSort]string{
+	CommentSortNewest:        "newest",
+	CommentSortMostHelpful:   "most_helpful",
+	CommentSortHighestRating: "rating_desc",
+	CommentSortLowestRating:  "rating_asc",
+}
+
+// CommentOptions configures the page of product comments to get
+type CommentOptions struct {
+	// Page is the page number, starting from 1. Zero means the first page.
+	Page int
+	// PageSize is the number of comments in each page. Zero uses the API default.
+	PageSize int
+	Sort     CommentSort
+}
+
+// Comment is a user comment about a product
+type Comment struct {
+	ID        CommentID `json:"Id"`
+	Author    string    `json:"AuthorName"`
+	CreatedAt Timestamp
+	// Rating is the score given by the user, from 1 to 5
+	Rating int `json:"Rate"`
+	Title  string
+	Body   string
+	Pros   []string `json:"Advantages"`
+	Cons   []string `json:"Disadvantages"`
+	// HelpfulVotes and UnhelpfulVotes are the votes of other users for the comment
+	HelpfulVotes   uint `json:"LikeCount"`
+	UnhelpfulVotes uint `json:"DislikeCount"`
+	// IsBuyer is true if the author has purchased the product
+	IsBuyer bool
+}
+
+// CommentsPage is a page of product comments
+type CommentsPage struct {
+	Comments  []Comment
+	Page      int `json:"PageNo"`
+	PageCount int
+	// Total is the number of comments of the product
+	Total int
+}
+
+func (c *Client) getProductCommentsAPIAddress(productID ProductID, options CommentOptions) string {
+	query := url.Values{}
+	if options.Page > 0 {
+		query.Set("pageno", strconv.Itoa(options.Page))
+	}
+	if options.PageSize > 0 {
+		query.Set("pagesize", strconv.Itoa(options.PageSize))
+	}
+	query.Set("sort", commentSortParameters[options.Sort])
+	return c.service2BaseURL + fmt.Sprintf(productCommentsAPIPath, productID, query.Encode())
+}
+
+// GetProductComments returns a page of the user comments of a product
+func (c *Client) GetProductComments(ctx context.Context, productID ProductID, options CommentOptions) (CommentsPage, error) {
+	apiAddress := c.getProductCommentsAPIAddress(productID, options)
+	return coalesce(c, ctx, EndpointProductComments, apiAddress, func(ctx context.Context) (CommentsPage, error) {
+		return c.getProductComments(ctx, apiAddress)
+	})
+}
+
+func (c *Client) getProductComments(ctx context.Context, apiAddress string) (CommentsPage, error) {
+	headers := getRequestHeaders()
+
+	var page CommentsPage
+	err := c.get(ctx, EndpointProductComments, apiAddress, headers, func(body []byte) error {
+		return decodeService2Response(body, apiAddress, &page)
+	})
+	if err != nil {
+		return CommentsPage{}, err
+	}
+	if page.Comments == nil {
+		page.Comments = []Comment{}
+	}
+	return page, nil
+}
@@ -0,0 +1,81 @@
+package dgkala_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mamal72/dgkala"
+	"github.com/mamal72/dgkala/dgkalatest"
+)
+
+func TestClient_GetProductComments(t *testing.T) {
+	comments := []dgkala.Comment{
+		{
+			ID:           1,
+			Author:       "علی",
+			CreatedAt:    dgkala.Timestamp{Time: time.Date(2017, 5, 1, 10, 0, 0, 0, dgkala.TehranLocation())},
+			Rating:       5,
+			Title:        "عالی",
+			Body:         "خیلی خوب است",
+			Pros:         []string{"جادار"},
+			Cons:         []string{"گران"},
+			HelpfulVotes: 3,
+			IsBuyer:      true,
+		},
+		{ID: 2, CreatedAt: dgkala.Timestamp{Time: time.Date(2017, 5, 3, 10, 0, 0, 0, dgkala.TehranLocation())}, Rating: 2, HelpfulVotes: 10},
+		{ID: 3, CreatedAt: dgkala.Timestamp{Time: time.Date(2017, 5, 2, 10, 0, 0, 0, dgkala.TehranLocation())}, Rating: 4, HelpfulVotes: 1},
+	}
+	server := dgkalatest.NewServer(dgkalatest.WithComments(6071, comments...))
+	defer server.Close()
+	client := server.Client()
+
+	tests := []struct {
+		name          string
+		options       dgkala.CommentOptions
+		wantIDs       []dgkala.CommentID
+		wantPageCount int
+	}{
+		{"Test should return newest comments first", dgkala.CommentOptions{}, []dgkala.CommentID{2, 3, 1}, 1},
+		{"Test should sort by helpful votes", dgkala.CommentOptions{Sort: dgkala.CommentSortMostHelpful}, []dgkala.CommentID{2, 1, 3}, 1},
+		{"Test should sort by rating", dgkala.CommentOptions{Sort: dgkala.CommentSortLowestRating}, []dgkala.CommentID{2, 3, 1}, 1},
+		{"Test should paginate", dgkala.CommentOptions{Page: 2, PageSize: 2, Sort: dgkala.CommentSortHighestRating}, []dgkala.CommentID{2}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetProductComments(context.Background(), 6071, tt.options)
+			if err != nil {
+				t.Fatalf("GetProductComments() error = %v", err)
+			}
+			var IDs []dgkala.CommentID
+			for _, comment := range got.Comments {
+				IDs = append(IDs, comment.ID)
+			}
+			if !reflect.DeepEqual(IDs, tt.wantIDs) {
+				t.Errorf("GetProductComments() IDs = %v, want %v", IDs, tt.wantIDs)
+			}
+			if got.Total != 3 || got.PageCount != tt.wantPageCount {
+				t.Errorf("GetProductComments() total = %v, page count = %v, want 3, %v", got.Total, got.PageCount, tt.wantPageCount)
+			}
+		})
+	}
+
+	t.Run("Test should decode every comment field", func(t *testing.T) {
+		got, err := client.GetProductComments(context.Background(), 6071, dgkala.CommentOptions{Sort: dgkala.CommentSortHighestRating, PageSize: 1})
+		if err != nil {
+			t.Fatalf("GetProductComments() error = %v", err)
+		}
+		if !reflect.DeepEqual(got.Comments, comments[:1]) {
+			t.Errorf("GetProductComments() = %+v, want %+v", got.Comments, comments[:1])
+		}
+	})
+
+	t.Run("Test should return ErrNotFound for unknown products", func(t *testing.T) {
+		_, err := client.GetProductComments(context.Background(), 1, dgkala.CommentOptions{})
+		if !errors.Is(err, dgkala.ErrNotFound) {
+			t.Errorf("GetProductComments() error = %v, want %v", err, dgkala.ErrNotFound)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package dgkala
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"time"
+
+	"github.com/mamal72/dgkala/jalali"
+)
+
+// tehranTZData is the Asia/Tehran time zone, used if the system has no time zone database
+//
+//go:embed zoneinfo/Asia_Tehran
+var tehranTZData []byte
+
+var tehranLocation = loadTehranLocation()
+
+func loadTehranLocation() *time.Location {
+	if location, err := time.LoadLocation("Asia/Tehran"); err == nil {
+		return location
+	}
+	if location, err := time.LoadLocationFromTZData("Asia/Tehran", tehranTZData); err == nil {
+		return location
+	}
+	return time.FixedZone("Asia/Tehran", 3*60*60+30*60)
+}
+
+// TehranLocation returns the Asia/Tehran time zone which DGKala timestamps are in
+func TehranLocation() *time.Location {
+	return tehranLocation
+}
+
+// parseAPITime parses a timestamp of an API response in Tehran time. Empty timestamps are the zero time.
+func parseAPITime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(apiTimeLayout, value, tehranLocation)
+}
+
+// formatAPITime formats a time like the timestamps of API responses
+func formatAPITime(t time.Time) string {
+	return t.In(tehranLocation).Format(apiTimeLayout)
+}
+
+// Timestamp is a time which is decoded from and encoded to JSON like the timestamps of API responses, in Tehran time.
+// Missing, null and empty timestamps are the zero time, which is encoded as null.
+type Timestamp struct {
+	time.Time
+}
+
+// MarshalJSON encodes the timestamp using the API timestamp layout
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(formatAPITime(t.Time))
+}
+
+// UnmarshalJSON decodes the timestamp using the API timestamp layout
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := parseAPITime(value)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// jalaliDate returns the Jalali date of a time in Tehran
+func jalaliDate(t time.Time) jalali.Date {
+	return jalali.FromTime(t.In(tehranLocation))
+}
+
+// JalaliRegisteredDate returns the Jalali date the product was registered on
+func (r ProductSearchResult) JalaliRegisteredDate() jalali.Date {
+	return jalaliDate(r.RegisteredDateTime)
+}
+
+// JalaliCreatedDate returns the Jalali date the comment was created on
+func (c Comment) JalaliCreatedDate() jalali.Date {
+	return jalaliDate(c.CreatedAt.Time)
+}
+
+// JalaliCreatedDate returns the Jalali date the question was asked on
+func (q Question) JalaliCreatedDate() jalali.Date {
+	return jalaliDate(q.CreatedAt.Time)
+}
+
+// JalaliCreatedDate returns the Jalali date the answer was created on
+func (a Answer) JalaliCreatedDate() jalali.Date {
+	return jalaliDate(a.CreatedAt.Time)
+}
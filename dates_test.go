@@ -0,0 +1,95 @@
+package dgkala
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mamal72/dgkala/jalali"
+)
+
+func Test_tehranTZData(t *testing.T) {
+	location, err := time.LoadLocationFromTZData("Asia/Tehran", tehranTZData)
+	if err != nil {
+		t.Fatalf("LoadLocationFromTZData() error = %v", err)
+	}
+	_, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, location).Zone()
+	if offset != 3*60*60+30*60 {
+		t.Errorf("Asia/Tehran offset = %v, want 3h30m", time.Duration(offset)*time.Second)
+	}
+}
+
+func Test_parseAPITime(t *testing.T) {
+	got, err := parseAPITime("2024-03-19T22:30:00")
+	if err != nil {
+		t.Fatalf("parseAPITime() error = %v", err)
+	}
+	if want := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("parseAPITime() = %v, want %v", got, want)
+	}
+	if got := formatAPITime(got.UTC()); got != "2024-03-19T22:30:00" {
+		t.Errorf("formatAPITime() = %v, want 2024-03-19T22:30:00", got)
+	}
+}
+
+func TestComment_JalaliCreatedDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		want      jalali.Date
+	}{
+		{"Test should convert the date in Tehran", time.Date(2024, 3, 19, 21, 0, 0, 0, time.UTC), jalali.Date{Year: 1403, Month: jalali.Farvardin, Day: 1}},
+		{"Test should return the zero date for zero times", time.Time{}, jalali.Date{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Comment{CreatedAt: Timestamp{tt.createdAt}}).JalaliCreatedDate(); got != tt.want {
+				t.Errorf("JalaliCreatedDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp_JSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     time.Time
+		wantJSON string
+	}{
+		{"Test should decode API timestamps in Tehran time", `"2024-03-19T22:30:00"`, time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC), `"2024-03-19T22:30:00"`},
+		{"Test should decode empty timestamps as the zero time", `""`, time.Time{}, `null`},
+		{"Test should decode null as the zero time", `null`, time.Time{}, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Timestamp
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			if string(encoded) != tt.wantJSON {
+				t.Errorf("MarshalJSON() = %s, want %s", encoded, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestComment_UnmarshalJSON(t *testing.T) {
+	var comment Comment
+	if err := json.Unmarshal([]byte(`{"Id":1,"Title":"عالی"}`), &comment); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if comment.ID != 1 || !comment.CreatedAt.IsZero() {
+		t.Errorf("Unmarshal() = %+v, want comment 1 without a date", comment)
+	}
+	if err := json.Unmarshal([]byte(`{"CreatedAt":"yesterday"}`), &comment); err == nil {
+		t.Errorf("Unmarshal() error = nil, want an error for invalid timestamps")
+	}
+}
@@ -0,0 +1,156 @@
+package dgkala
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buger/jsonparser"
+)
+
+// DecodeMode defines how search results with missing or mistyped fields are handled
+type DecodeMode int
+
+const (
+	// DecodeLenient decodes what it can and reports problems as SearchResult.Warnings
+	DecodeLenient DecodeMode = iota
+	// DecodeStrict fails with a *DecodeReport if any field has a problem
+	DecodeStrict
+)
+
+// WithDecodeMode sets how search results with missing or mistyped fields are handled.
+// DecodeLenient is used by default.
+func WithDecodeMode(mode DecodeMode) Option {
+	return func(c *Client) {
+		c.decodeMode = mode
+	}
+}
+
+var (
+	// ErrMissingField is matched by problems of missing or null fields
+	ErrMissingField = errors.New("dgkala: missing field")
+	// ErrFieldType is matched by problems of fields with unexpected types or values
+	ErrFieldType = errors.New("dgkala: mistyped field")
+)
+
+// FieldProblem is a problem decoding a field of a search result
+type FieldProblem struct {
+	// Hit is the index of the result in the response, or -1 for problems of aggregations
+	Hit int
+	// Field is the path of the field, like ProductColorList[0].ColorHex
+	Field string
+	Err   error
+}
+
+func (p FieldProblem) String() string {
+	if p.Hit == aggregationsHit {
+		return fmt.Sprintf("%s: %v", p.Field, p.Err)
+	}
+	return fmt.Sprintf("hit %d: %s: %v", p.Hit, p.Field, p.Err)
+}
+
+// DecodeReport is the error returned in DecodeStrict mode when some fields can't be decoded.
+// It's wrapped in a *DecodeError.
+type DecodeReport struct {
+	Problems []FieldProblem
+}
+
+func (r *DecodeReport) Error() string {
+	problems := make([]string, len(r.Problems))
+	for i, problem := range r.Problems {
+		problems[i] = problem.String()
+	}
+	return fmt.Sprintf("%d field problems: %s", len(r.Problems), strings.Join(problems, "; "))
+}
+
+// fieldDecoder reads fields of a JSON object and records the problems it finds
+type fieldDecoder struct {
+	value    []byte
+	hit      int
+	prefix   string
+	problems *[]FieldProblem
+}
+
+func (d *fieldDecoder) report(field string, err error) {
+	*d.problems = append(*d.problems, FieldProblem{d.hit, d.prefix + field, err})
+}
+
+func (d *fieldDecoder) lookup(field string, want jsonparser.ValueType) ([]byte, bool) {
+	raw, dataType, _, err := jsonparser.Get(d.value, field)
+	switch {
+	case err == jsonparser.KeyPathNotFoundError || dataType == jsonparser.Null:
+		d.report(field, ErrMissingField)
+	case err != nil:
+		d.report(field, err)
+	case dataType != want:
+		d.report(field, fmt.Errorf("%w: got %s, want %s", ErrFieldType, dataType, want))
+	default:
+		return raw, true
+	}
+	return nil, false
+}
+
+func (d *fieldDecoder) getInt(field string) int64 {
+	raw, ok := d.lookup(field, jsonparser.Number)
+	if !ok {
+		return 0
+	}
+	value, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		d.report(field, fmt.Errorf("%w: %v", ErrFieldType, err))
+	}
+	return value
+}
+
+func (d *fieldDecoder) getString(field string) string {
+	raw, ok := d.lookup(field, jsonparser.String)
+	if !ok {
+		return ""
+	}
+	value, err := jsonparser.ParseString(raw)
+	if err != nil {
+		d.report(field, fmt.Errorf("%w: %v", ErrFieldType, err))
+	}
+	return value
+}
+
+func (d *fieldDecoder) getBoolean(field string) bool {
+	raw, ok := d.lookup(field, jsonparser.Boolean)
+	if !ok {
+		return false
+	}
+	value, _ := jsonparser.ParseBoolean(raw)
+	return value
+}
+
+func (d *fieldDecoder) getTime(field string) time.Time {
+	raw, ok := d.lookup(field, jsonparser.String)
+	if !ok {
+		return time.Time{}
+	}
+	value, err := parseAPITime(string(raw))
+	if err != nil {
+		d.report(field, fmt.Errorf("%w: %v", ErrFieldType, err))
+	}
+	return value
+}
+
+// each calls callback with a decoder for every object of an array field
+func (d *fieldDecoder) each(field string, callback func(item *fieldDecoder)) {
+	raw, ok := d.lookup(field, jsonparser.Array)
+	if !ok {
+		return
+	}
+	index := 0
+	jsonparser.ArrayEach(raw, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+		callback(&fieldDecoder{
+			value:    value,
+			hit:      d.hit,
+			prefix:   fmt.Sprintf("%s%s[%d].", d.prefix, field, index),
+			problems: d.problems,
+		})
+		index++
+	})
+}
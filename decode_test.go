@@ -0,0 +1,106 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const driftedSearchResponse = `{"took":1,"hits":{"total":2,"hits":[
+	{"_source":{"Id":1,"EnTitle":"Phone","FaTitle":"گوشی","ImagePath":"1.jpg","ExistStatus":2,"IsActive":true,
+		"UrlCode":"phone","Rate":80,"MinPrice":1000,"MaxPrice":2000,"LikeCounter":1,"LastPeriodLikeCounter":1,
+		"ViewCounter":1,"LastPeriodViewCounter":1,"IsSpecialOffer":false,"RegDateTime":"2017-05-01T12:00:00",
+		"HasVideo":false,"ProductColorList":[{"ColorTitle":"Black","ColorHex":"#000","ColorCode":"black"}],
+		"UserRating":1,"FavoriteCounter":1,"LastPeriodFavoriteCounter":1,"LastPeriodSaleCounter":1,
+		"HasGift":false,"DetailSource":""}},
+	{"_source":{"Id":2,"EnTitle":"Case","FaTitle":"قاب","ImagePath":"2.jpg","ExistStatus":2,"IsActive":true,
+		"UrlCode":"case","Rate":80,"Price":1000,"MaxPrice":"2000","LikeCounter":1,"LastPeriodLikeCounter":1,
+		"ViewCounter":1,"LastPeriodViewCounter":1,"IsSpecialOffer":false,"RegDateTime":"yesterday",
+		"HasVideo":false,"ProductColorList":[{"ColorTitle":"Black","ColorCode":"black"}],
+		"UserRating":1,"FavoriteCounter":1,"LastPeriodFavoriteCounter":1,"LastPeriodSaleCounter":1,
+		"HasGift":false,"DetailSource":null}}
+]}}`
+
+func TestClient_decodeModes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(driftedSearchResponse))
+	}))
+	defer server.Close()
+
+	wantProblems := []struct {
+		field string
+		err   error
+	}{
+		{"_source.MinPrice", ErrMissingField},
+		{"_source.MaxPrice", ErrFieldType},
+		{"_source.RegDateTime", ErrFieldType},
+		{"_source.ProductColorList[0].ColorHex", ErrMissingField},
+		{"_source.DetailSource", ErrMissingField},
+	}
+	checkProblems := func(t *testing.T, problems []FieldProblem) {
+		if len(problems) != len(wantProblems) {
+			t.Fatalf("problems = %v, want %v problems", problems, len(wantProblems))
+		}
+		for i, want := range wantProblems {
+			got := problems[i]
+			if got.Hit != 1 || got.Field != want.field || !errors.Is(got.Err, want.err) {
+				t.Errorf("problem %d = %v, want hit 1: %s: %v", i, got, want.field, want.err)
+			}
+		}
+	}
+
+	t.Run("Test should return warnings in lenient mode", func(t *testing.T) {
+		client := NewClient(WithSearchBaseURL(server.URL))
+		got, err := client.Search(context.Background(), "phone")
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(got.Results) != 2 || !got.Results[1].MinimumPrice.IsZero() || got.Results[1].PersianTitle != "قاب" {
+			t.Errorf("Search() results = %+v", got.Results)
+		}
+		checkProblems(t, got.Warnings)
+	})
+
+	t.Run("Test should fail with a DecodeReport in strict mode", func(t *testing.T) {
+		client := NewClient(WithSearchBaseURL(server.URL), WithDecodeMode(DecodeStrict))
+		_, err := client.Search(context.Background(), "phone")
+		var decodeErr *DecodeError
+		var report *DecodeReport
+		if !errors.As(err, &decodeErr) || !errors.As(err, &report) {
+			t.Fatalf("Search() error = %v, want a DecodeReport", err)
+		}
+		checkProblems(t, report.Problems)
+	})
+}
+
+func TestClient_malformedAggregations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"took":1,"hits":{"total":0,"hits":[]},"aggregations":{"status":{"buckets":[{"key":"unknown","doc_count":1}]}}}`))
+	}))
+	defer server.Close()
+
+	t.Run("Test should fall back to local facets in lenient mode", func(t *testing.T) {
+		client := NewClient(WithSearchBaseURL(server.URL))
+		got, err := client.Search(context.Background(), "phone")
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if !got.Facets.Local {
+			t.Errorf("Search().Facets.Local = false, want true")
+		}
+		if len(got.Warnings) != 1 || got.Warnings[0].Field != "aggregations.status.buckets[0].key" || !errors.Is(got.Warnings[0].Err, ErrFieldType) {
+			t.Errorf("Search().Warnings = %v, want a status key problem", got.Warnings)
+		}
+	})
+
+	t.Run("Test should fail with a DecodeReport in strict mode", func(t *testing.T) {
+		client := NewClient(WithSearchBaseURL(server.URL), WithDecodeMode(DecodeStrict))
+		_, err := client.Search(context.Background(), "phone")
+		var report *DecodeReport
+		if !errors.As(err, &report) || len(report.Problems) != 1 {
+			t.Errorf("Search() error = %v, want a DecodeReport with 1 problem", err)
+		}
+	})
+}
@@ -1,21 +1,28 @@
 package dgkala
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/buger/jsonparser"
 )
 
 const (
-	incredibleOffersAPIAddress = "https://service2.digikala.com/api/IncredibleOffer/GetIncredibleOffer"
-	searchAPIAddress           = "https://search.digikala.com/api/search?keyword=%s"
-	staticFilesPath            = "https://file.digikala.com/digikala/%s"
-	productByIDAPIAddress      = "https://service2.digikala.com/api/ProductCache/GetProductById/%d"
+	incredibleOffersAPIPath = "/api/IncredibleOffer/GetIncredibleOffer"
+	searchAPIPath           = "/api/search?%s"
+	staticFilesPath         = "/digikala/%s"
+	productByIDAPIPath      = "/api/ProductCache/GetProductById/%d"
+	productCommentsAPIPath  = "/api/Comment/GetProductComments/%d?%s"
+	productQuestionsAPIPath = "/api/Question/GetProductQuestions/%d?%s"
+	categoriesAPIPath       = "/api/Category/GetCategories"
+	suggestAPIPath          = "/api/autocomplete?%s"
+
+	// apiTimeLayout is the layout of timestamps in API responses
+	apiTimeLayout = "2006-01-02T15:04:05"
 )
 
 type requestHeader map[string]string
@@ -42,20 +49,23 @@ type ImagePaths struct {
 // IncredibleOffer is a struct containing
 // DGKala incredible offer properties
 type IncredibleOffer struct {
-	ID                 uint
-	ProductID          uint
-	Title              string
-	ImagePaths         ImagePaths
-	BannerPath         string
-	BannerPathMobile   string
-	BannerPathTablet   string
-	Row                uint
-	ProductTitleFa     string
-	ProductTitleEn     string
-	Discount           uint
-	Price              uint
+	ID               OfferID
+	ProductID        ProductID
+	Title            string
+	ImagePaths       ImagePaths
+	BannerPath       string
+	BannerPathMobile string
+	BannerPathTablet string
+	Row              uint
+	ProductTitleFa   string
+	ProductTitleEn   string
+	// Discount is the amount taken off the original price of the product
+	Discount Money
+	// Price is the price of the product after the discount
+	Price              Money
 	OnlyForApplication bool
 	OnlyForMembers     bool
+	Raw                RawJSON `json:"-"`
 }
 
 type incredibleOffersResponse struct {
@@ -72,7 +82,7 @@ type ProductColor struct {
 
 // ProductSearchResult is a struct containing a product details for a search result
 type ProductSearchResult struct {
-	ID                  int64
+	ID                  ProductID
 	EnglishTitle        string
 	PersianTitle        string
 	Image               string
@@ -80,8 +90,8 @@ type ProductSearchResult struct {
 	IsActive            bool
 	URL                 string
 	Rate                int64
-	MinimumPrice        int64
-	MaximumPrice        int64
+	MinimumPrice        Money
+	MaximumPrice        Money
 	Likes               int64
 	LastPeriodLikes     int64
 	Views               int64
@@ -96,6 +106,8 @@ type ProductSearchResult struct {
 	LastPeriodSales     int64
 	HasGift             bool
 	HTMLDetails         string
+	// Raw is the _source object of the search hit
+	Raw RawJSON `json:"-"`
 }
 
 // SearchResult returns a struct containing results of the search for a keyword
@@ -103,143 +115,238 @@ type SearchResult struct {
 	ResponseTime int64
 	Count        int64
 	Results      []ProductSearchResult
+	Facets       SearchFacets
+	// Warnings lists the fields of results and aggregations which couldn't be decoded in DecodeLenient mode.
+	// Facets are computed from the results if the aggregations have problems.
+	Warnings []FieldProblem
+	// hits is the number of hits in the response, including the ones which couldn't be decoded
+	hits int
 }
 
 // ProductByIDResult returns a struct containing results of the request for product details by ID
 type ProductByIDResult struct {
-	Data ProductByID
+	Data   ProductByID
+	Status string
 }
 
 // ProductByID is a struct containing a product details when you get it by ID
 type ProductByID struct {
-	ID                uint   `json:"ProductId"`
-	EnglishTitle      string `json:"EnTitle"`
-	PersianTitle      string `json:"FaTitle"`
+	ID                ProductID `json:"ProductId"`
+	EnglishTitle      string    `json:"EnTitle"`
+	PersianTitle      string    `json:"FaTitle"`
 	Description       string
 	ImagePaths        ImagePaths
 	IsIncredibleOffer bool
 	Strengths         string
 	Weaknesses        string
-	MinPrice          uint
+	MinPrice          Money
+	Specifications    []SpecificationGroup
+	Categories        []ProductCategory `json:"CategoryPath"`
+	Brand             Brand
+	Variants          []ProductVariant
+	Sellers           []SellerOffer
+	Rating            ProductRating
+	// Weight is the weight of the product in grams
+	Weight     float64
+	Dimensions ProductDimensions
+	Raw        RawJSON `json:"-"`
 }
 
-func sendRequest(address string, headers requestHeader) (*http.Response, error) {
-	request, err := http.NewRequest(http.MethodGet, address, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	for key, value := range headers {
-		request.Header.Add(key, value)
-	}
+func (c *Client) getIncredibleOffersAPIAddress() string {
+	return c.service2BaseURL + incredibleOffersAPIPath
+}
 
-	var client http.Client
-	return client.Do(request)
+func (c *Client) getStaticResourceAddress(resourcePath string) string {
+	return c.fileBaseURL + fmt.Sprintf(staticFilesPath, resourcePath)
 }
 
-func getStaticResourceAddress(resourcePath string) string {
-	return fmt.Sprintf(staticFilesPath, resourcePath)
+func (c *Client) getSearchAPIAddress(searchQuery *SearchQuery, options SearchOptions) string {
+	query := searchQuery.values()
+	query.Set("keyword", c.normalizeKeyword(query.Get("keyword")))
+	if from := options.from(); from > 0 {
+		query.Set("from", strconv.Itoa(from))
+	}
+	query.Set("size", strconv.Itoa(options.size()))
+	return c.searchBaseURL + fmt.Sprintf(searchAPIPath, query.Encode())
 }
 
-func getSearchAPIAddress(keyword string) string {
-	query := url.QueryEscape(keyword)
-	return fmt.Sprintf(searchAPIAddress, query)
+func (c *Client) getProductByIDAPIAddress(productID ProductID) string {
+	return c.service2BaseURL + fmt.Sprintf(productByIDAPIPath, productID)
 }
 
-func getProductByIDAPIAddress(productID int) string {
-	return fmt.Sprintf(productByIDAPIAddress, productID)
+// isStatusOK reports whether a DGKala API Status field means success.
+// Some endpoints omit the field, so an empty status is considered successful too.
+func isStatusOK(status string) bool {
+	return status == "" || strings.EqualFold(status, "ok")
 }
 
 func getRequestHeaders() requestHeader {
 	return map[string]string{"ApplicationVersion": "1.4.1"}
 }
 
-// IncredibleOffers get a slice of DGKala IncredibleOffer items
+// IncredibleOffers get a slice of DGKala IncredibleOffer items using the default client
 func IncredibleOffers() ([]IncredibleOffer, error) {
-	headers := getRequestHeaders()
-	response, err := sendRequest(incredibleOffersAPIAddress, headers)
-	if err != nil {
-		return nil, err
-	}
+	return IncredibleOffersContext(context.Background())
+}
 
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
-	}
-	var offersResponse incredibleOffersResponse
-	err = json.Unmarshal(body, &offersResponse)
+// IncredibleOffersContext is like IncredibleOffers but accepts a context
+func IncredibleOffersContext(ctx context.Context) ([]IncredibleOffer, error) {
+	return defaultClient.IncredibleOffers(ctx)
+}
+
+// Search for a product in DGKala using the default client
+func Search(keyword string) (SearchResult, error) {
+	return SearchContext(context.Background(), keyword)
+}
+
+// SearchContext is like Search but accepts a context
+func SearchContext(ctx context.Context, keyword string) (SearchResult, error) {
+	return defaultClient.Search(ctx, keyword)
+}
+
+// GetProductByID returns a product by getting it's ID using the default client
+func GetProductByID(productID ProductID) (ProductByID, error) {
+	return GetProductByIDContext(context.Background(), productID)
+}
+
+// GetProductByIDContext is like GetProductByID but accepts a context
+func GetProductByIDContext(ctx context.Context, productID ProductID) (ProductByID, error) {
+	return defaultClient.GetProductByID(ctx, productID)
+}
+
+// IncredibleOffers get a slice of DGKala IncredibleOffer items
+func (c *Client) IncredibleOffers(ctx context.Context) ([]IncredibleOffer, error) {
+	return coalesce(c, ctx, EndpointIncredibleOffers, c.getIncredibleOffersAPIAddress(), c.incredibleOffers)
+}
+
+func (c *Client) incredibleOffers(ctx context.Context) ([]IncredibleOffer, error) {
+	headers := getRequestHeaders()
+	apiAddress := c.getIncredibleOffersAPIAddress()
+	var incredibleOffers []IncredibleOffer
+	err := c.get(ctx, EndpointIncredibleOffers, apiAddress, headers, func(body []byte) error {
+		var offersResponse incredibleOffersResponse
+		if err := json.Unmarshal(body, &offersResponse); err != nil {
+			return &DecodeError{URL: apiAddress, Err: err}
+		}
+		if !isStatusOK(offersResponse.Status) {
+			return &APIError{Status: offersResponse.Status, URL: apiAddress}
+		}
+		incredibleOffers = offersResponse.Data
+		if c.rawJSON {
+			index := 0
+			jsonparser.ArrayEach(body, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+				if index < len(incredibleOffers) {
+					incredibleOffers[index].Raw = newRawJSON(value)
+				}
+				index++
+			}, "Data")
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	incredibleOffers := offersResponse.Data
 	return incredibleOffers, nil
 }
 
 // Search for a product in DGKala and return a slice of DGKala SearchResult items
-func Search(keyword string) (SearchResult, error) {
-	searchAddress := getSearchAPIAddress(keyword)
-	httpResponse, err := sendRequest(searchAddress, requestHeader{})
-	if err != nil {
-		return SearchResult{}, err
-	}
+func (c *Client) Search(ctx context.Context, keyword string) (SearchResult, error) {
+	return c.SearchWithOptions(ctx, keyword, SearchOptions{})
+}
+
+// SearchWithOptions searches for a product in DGKala and returns a page of results
+func (c *Client) SearchWithOptions(ctx context.Context, keyword string, options SearchOptions) (SearchResult, error) {
+	return c.SearchQuery(ctx, NewSearchQuery(keyword), options)
+}
+
+// SearchQuery searches for products matching a query in DGKala and returns a page of results
+func (c *Client) SearchQuery(ctx context.Context, query *SearchQuery, options SearchOptions) (SearchResult, error) {
+	searchAddress := c.getSearchAPIAddress(query, options)
+	return coalesce(c, ctx, EndpointSearch, searchAddress, func(ctx context.Context) (SearchResult, error) {
+		return c.searchQuery(ctx, searchAddress)
+	})
+}
 
-	responseBody, err := ioutil.ReadAll(httpResponse.Body)
+func (c *Client) searchQuery(ctx context.Context, searchAddress string) (SearchResult, error) {
+	var result SearchResult
+	err := c.get(ctx, EndpointSearch, searchAddress, requestHeader{}, func(body []byte) error {
+		var err error
+		result, err = c.decodeSearchResponse(searchAddress, body)
+		return err
+	})
 	if err != nil {
 		return SearchResult{}, err
 	}
+	return result, nil
+}
 
+// decodeSearchResponse decodes the results and facets of a search response
+func (c *Client) decodeSearchResponse(searchAddress string, responseBody []byte) (SearchResult, error) {
 	responseTime, err := jsonparser.GetInt(responseBody, "took")
 	if err != nil {
-		return SearchResult{}, err
+		return SearchResult{}, &DecodeError{URL: searchAddress, Err: err}
 	}
 
 	count, err := jsonparser.GetInt(responseBody, "hits", "total")
 	if err != nil {
-		return SearchResult{}, err
+		return SearchResult{}, &DecodeError{URL: searchAddress, Err: err}
 	}
 
 	productSearchResults := []ProductSearchResult{}
+	problems := []FieldProblem{}
 	realResultsJSONPath := []string{"hits", "hits"}
 	parentJSONResultKey := "_source"
-	jsonparser.ArrayEach(responseBody, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
-		ID, _ := jsonparser.GetInt(value, parentJSONResultKey, "Id")
-		englishTitle, _ := jsonparser.GetString(value, parentJSONResultKey, "EnTitle")
-		persianTitle, _ := jsonparser.GetString(value, parentJSONResultKey, "FaTitle")
-		imagePath, _ := jsonparser.GetString(value, parentJSONResultKey, "ImagePath")
-		image := getStaticResourceAddress(imagePath)
-		existsStatusInt, _ := jsonparser.GetInt(value, parentJSONResultKey, "ExistStatus")
+	index := 0
+	err = jsonparser.ArrayEach(responseBody, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+		source, _, _, sourceErr := jsonparser.Get(value, parentJSONResultKey)
+		hit := &fieldDecoder{source, index, parentJSONResultKey + ".", &problems}
+		index++
+		if sourceErr != nil {
+			hit.report("", ErrMissingField)
+			return
+		}
+
+		ID := ProductID(hit.getInt("Id"))
+		englishTitle := hit.getString("EnTitle")
+		persianTitle := hit.getString("FaTitle")
+		imagePath := hit.getString("ImagePath")
+		image := c.getStaticResourceAddress(imagePath)
+		existsStatusInt := hit.getInt("ExistStatus")
 		existsStatus := ProductExistsStatus(existsStatusInt)
-		isActive, _ := jsonparser.GetBoolean(value, parentJSONResultKey, "IsActive")
-		URL, _ := jsonparser.GetString(value, parentJSONResultKey, "UrlCode")
-		rate, _ := jsonparser.GetInt(value, parentJSONResultKey, "Rate")
-		minimumPrice, _ := jsonparser.GetInt(value, parentJSONResultKey, "MinPrice")
-		maximumPrice, _ := jsonparser.GetInt(value, parentJSONResultKey, "MaxPrice")
-		likes, _ := jsonparser.GetInt(value, parentJSONResultKey, "LikeCounter")
-		lastPeriodLikes, _ := jsonparser.GetInt(value, parentJSONResultKey, "LastPeriodLikeCounter")
-		views, _ := jsonparser.GetInt(value, parentJSONResultKey, "ViewCounter")
-		lastPeriodViews, _ := jsonparser.GetInt(value, parentJSONResultKey, "LastPeriodViewCounter")
-		isSpecialOffer, _ := jsonparser.GetBoolean(value, parentJSONResultKey, "IsSpecialOffer")
-		regDateTimeString, _ := jsonparser.GetString(value, parentJSONResultKey, "RegDateTime")
-		registeredDateTime, _ := time.Parse("2006-01-02T15:04:05", regDateTimeString)
-		hasVideo, _ := jsonparser.GetBoolean(value, parentJSONResultKey, "HasVideo")
+		isActive := hit.getBoolean("IsActive")
+		URL := hit.getString("UrlCode")
+		rate := hit.getInt("Rate")
+		minimumPrice := Rials(hit.getInt("MinPrice"))
+		maximumPrice := Rials(hit.getInt("MaxPrice"))
+		likes := hit.getInt("LikeCounter")
+		lastPeriodLikes := hit.getInt("LastPeriodLikeCounter")
+		views := hit.getInt("ViewCounter")
+		lastPeriodViews := hit.getInt("LastPeriodViewCounter")
+		isSpecialOffer := hit.getBoolean("IsSpecialOffer")
+		registeredDateTime := hit.getTime("RegDateTime")
+		hasVideo := hit.getBoolean("HasVideo")
 		colors := []ProductColor{}
-		jsonparser.ArrayEach(value, func(colorsValue []byte, _ jsonparser.ValueType, _ int, _ error) {
-			colorTitle, _ := jsonparser.GetString(colorsValue, "ColorTitle")
-			colorHex, _ := jsonparser.GetString(colorsValue, "ColorHex")
-			colorCode, _ := jsonparser.GetString(colorsValue, "ColorCode")
+		hit.each("ProductColorList", func(color *fieldDecoder) {
+			colorTitle := color.getString("ColorTitle")
+			colorHex := color.getString("ColorHex")
+			colorCode := color.getString("ColorCode")
 			currentColor := ProductColor{
 				colorTitle,
 				colorHex,
 				colorCode,
 			}
 			colors = append(colors, currentColor)
-		}, parentJSONResultKey, "ProductColorList")
-		userRatingCount, _ := jsonparser.GetInt(value, parentJSONResultKey, "UserRating")
-		favorites, _ := jsonparser.GetInt(value, parentJSONResultKey, "FavoriteCounter")
-		lastPeriodFavorites, _ := jsonparser.GetInt(value, parentJSONResultKey, "LastPeriodFavoriteCounter")
-		lastPeriodSales, _ := jsonparser.GetInt(value, parentJSONResultKey, "LastPeriodSaleCounter")
-		hasGift, _ := jsonparser.GetBoolean(value, parentJSONResultKey, "HasGift")
-		hTMLDetails, _ := jsonparser.GetString(value, parentJSONResultKey, "DetailSource")
+		})
+		userRatingCount := hit.getInt("UserRating")
+		favorites := hit.getInt("FavoriteCounter")
+		lastPeriodFavorites := hit.getInt("LastPeriodFavoriteCounter")
+		lastPeriodSales := hit.getInt("LastPeriodSaleCounter")
+		hasGift := hit.getBoolean("HasGift")
+		hTMLDetails := hit.getString("DetailSource")
+		var raw RawJSON
+		if c.rawJSON {
+			raw = newRawJSON(source)
+		}
 
 		currentProductSearchResult := ProductSearchResult{
 			ID,
@@ -266,35 +373,77 @@ func Search(keyword string) (SearchResult, error) {
 			lastPeriodSales,
 			hasGift,
 			hTMLDetails,
+			raw,
 		}
 		productSearchResults = append(productSearchResults, currentProductSearchResult)
 	}, realResultsJSONPath...)
+	if err != nil {
+		return SearchResult{}, &DecodeError{URL: searchAddress, Err: err}
+	}
+
+	facets, ok, facetProblems := parseSearchFacets(responseBody)
+	problems = append(problems, facetProblems...)
+	if len(problems) > 0 && c.decodeMode == DecodeStrict {
+		return SearchResult{}, &DecodeError{URL: searchAddress, Err: &DecodeReport{problems}}
+	}
+	if !ok || len(facetProblems) > 0 {
+		facets = localSearchFacets(productSearchResults)
+	}
 
 	result := SearchResult{
-		responseTime,
-		count,
-		productSearchResults,
+		ResponseTime: responseTime,
+		Count:        count,
+		Results:      productSearchResults,
+		Facets:       facets,
+		Warnings:     problems,
+		hits:         index,
 	}
 
 	return result, nil
 }
 
 // GetProductByID returns a product by getting it's ID
-func GetProductByID(productID int) (ProductByID, error) {
-	headers := getRequestHeaders()
-	apiAddress := getProductByIDAPIAddress(productID)
+func (c *Client) GetProductByID(ctx context.Context, productID ProductID) (ProductByID, error) {
+	apiAddress := c.getProductByIDAPIAddress(productID)
+	return coalesce(c, ctx, EndpointProductByID, apiAddress, func(ctx context.Context) (ProductByID, error) {
+		return c.getProductByID(ctx, productID, apiAddress)
+	})
+}
 
-	httpResponse, err := sendRequest(apiAddress, headers)
+func (c *Client) getProductByID(ctx context.Context, productID ProductID, apiAddress string) (ProductByID, error) {
+	headers := getRequestHeaders()
 
-	body, err := ioutil.ReadAll(httpResponse.Body)
-	if err != nil {
-		return ProductByID{}, err
-	}
-	var productByIDResult ProductByIDResult
-	err = json.Unmarshal(body, &productByIDResult)
+	var product ProductByID
+	err := c.get(ctx, EndpointProductByID, apiAddress, headers, func(body []byte) error {
+		var productByIDResult ProductByIDResult
+		if err := json.Unmarshal(body, &productByIDResult); err != nil {
+			return &DecodeError{URL: apiAddress, Err: err}
+		}
+		if !isStatusOK(productByIDResult.Status) {
+			return &APIError{Status: productByIDResult.Status, URL: apiAddress}
+		}
+		product = productByIDResult.Data
+		if product.ID == 0 {
+			return fmt.Errorf("dgkala: product %d: %w", productID, ErrNotFound)
+		}
+		if c.rawJSON {
+			raw, _, _, _ := jsonparser.Get(body, "Data")
+			product.Raw = newRawJSON(raw)
+		}
+		return nil
+	})
 	if err != nil {
 		return ProductByID{}, err
 	}
-	product := productByIDResult.Data
 	return product, nil
 }
+
+// GetOfferProduct returns the product of an incredible offer
+func (c *Client) GetOfferProduct(ctx context.Context, offer IncredibleOffer) (ProductByID, error) {
+	return c.GetProductByID(ctx, offer.ProductID)
+}
+
+// GetSearchResultProduct returns the details of a product found by a search
+func (c *Client) GetSearchResultProduct(ctx context.Context, result ProductSearchResult) (ProductByID, error) {
+	return c.GetProductByID(ctx, result.ID)
+}
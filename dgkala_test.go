@@ -1,11 +1,21 @@
 package dgkala
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
 
 func Test_sendRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("ApplicationVersion") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
 	type args struct {
 		address string
 		headers map[string]string
@@ -19,48 +29,42 @@ func Test_sendRequest(t *testing.T) {
 		{
 			name: "Test should send request and receive a 200 respose",
 			args: args{
-				address: "http://icanhazip.com/",
+				address: server.URL,
 				headers: map[string]string{"ApplicationVersion": "1.4.1"},
 			},
 			want:    200,
 			wantErr: false,
 		},
+		{
+			name: "Test should send request without headers and receive a 400 respose",
+			args: args{
+				address: server.URL,
+				headers: map[string]string{},
+			},
+			want:    400,
+			wantErr: false,
+		},
+		{
+			name: "Test should fail for invalid addresses",
+			args: args{
+				address: "://",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := sendRequest(tt.args.address, tt.args.headers)
+			got, err := defaultClient.sendRequest(context.Background(), tt.args.address, tt.args.headers)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("sendRequest() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got.StatusCode, tt.want) {
-				t.Errorf("sendRequest() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestIncredibleOffers(t *testing.T) {
-	tests := []struct {
-		name     string
-		wantType string
-		wantErr  bool
-	}{
-		{
-			name:     "Test should return a slice of incredible offers",
-			wantType: "[]dgkala.IncredibleOffer",
-			wantErr:  false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := IncredibleOffers()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("IncredibleOffers() error = %v, wantErr %v", err, tt.wantErr)
+			if err != nil {
 				return
 			}
-			if reflect.TypeOf(got).String() != tt.wantType {
-				t.Errorf("type of IncredibleOffers() = %v, want type of %v", reflect.TypeOf(got), tt.wantType)
+			defer got.Body.Close()
+			if !reflect.DeepEqual(got.StatusCode, tt.want) {
+				t.Errorf("sendRequest() = %v, want %v", got, tt.want)
 			}
 		})
 	}
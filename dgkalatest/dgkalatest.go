@@ -0,0 +1,599 @@
+// Package dgkalatest provides a fake DGKala API server for hermetic tests.
+//
+// The server emulates the incredible offers, search and product by ID endpoints
+// using fixtures and returns clients pre-wired to it:
+//
+//	server := dgkalatest.NewServer(dgkalatest.WithProducts(dgkala.ProductByID{ID: 6071}))
+//	defer server.Close()
+//	product, err := server.Client().GetProductByID(ctx, 6071)
+package dgkalatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mamal72/dgkala"
+)
+
+const (
+	incredibleOffersPath = "/api/IncredibleOffer/GetIncredibleOffer"
+	searchPath           = "/api/search"
+	productByIDPath      = "/api/ProductCache/GetProductById/"
+	productCommentsPath  = "/api/Comment/GetProductComments/"
+	productQuestionsPath = "/api/Question/GetProductQuestions/"
+	categoriesPath       = "/api/Category/GetCategories"
+	suggestPath          = "/api/autocomplete"
+
+	// defaultSearchSize is the number of search results per page if the size parameter is missing
+	defaultSearchSize = dgkala.DefaultSearchSize
+	// defaultPageSize is the number of items per page of paginated service2 endpoints
+	defaultPageSize = 10
+)
+
+// Server is a fake DGKala API server.
+// Its fixtures can be changed while it's running.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	offers        []dgkala.IncredibleOffer
+	products      map[dgkala.ProductID]dgkala.ProductByID
+	searchResults []dgkala.ProductSearchResult
+	searchFacets  *dgkala.SearchFacets
+	comments      map[dgkala.ProductID][]dgkala.Comment
+	questions     map[dgkala.ProductID][]dgkala.Question
+	categories    []dgkala.Category
+	suggestions   map[string]dgkala.Suggestions
+	errors        map[dgkala.Endpoint]int
+	latency       time.Duration
+	requests      map[dgkala.Endpoint]int
+
+	// resultCategories are the category IDs of search results, by product ID
+	resultCategories map[dgkala.ProductID][]dgkala.CategoryID
+}
+
+// Option is a functional option for configuring a Server
+type Option func(*Server)
+
+// WithOffers sets the incredible offers served by the server.
+// Fields of the Raw JSON of fixtures are served too, for this and the other fixture options.
+func WithOffers(offers ...dgkala.IncredibleOffer) Option {
+	return func(s *Server) {
+		s.offers = offers
+	}
+}
+
+// WithProducts adds products served by the product by ID endpoint
+func WithProducts(products ...dgkala.ProductByID) Option {
+	return func(s *Server) {
+		for _, product := range products {
+			s.products[product.ID] = product
+		}
+	}
+}
+
+// WithSearchResults sets the products served by the search endpoint, replacing the ones added before.
+// Results whose English or Persian title contains the keyword are returned,
+// filtered and sorted by the SearchQuery parameters and paginated using the from and size parameters.
+// The Image field is served as the image path of the product.
+func WithSearchResults(results ...dgkala.ProductSearchResult) Option {
+	return func(s *Server) {
+		s.searchResults = results
+	}
+}
+
+// WithComments adds comments of a product served by the product comments endpoint
+func WithComments(productID dgkala.ProductID, comments ...dgkala.Comment) Option {
+	return func(s *Server) {
+		s.comments[productID] = append(s.comments[productID], comments...)
+	}
+}
+
+// WithQuestions adds questions of a product served by the product questions endpoint
+func WithQuestions(productID dgkala.ProductID, questions ...dgkala.Question) Option {
+	return func(s *Server) {
+		s.questions[productID] = append(s.questions[productID], questions...)
+	}
+}
+
+// WithCategories sets the category tree served by the categories endpoint
+func WithCategories(categories ...dgkala.Category) Option {
+	return func(s *Server) {
+		s.categories = categories
+	}
+}
+
+// WithCategoryProducts adds search results which belong to a category,
+// so they match searches filtered by the category
+func WithCategoryProducts(categoryID dgkala.CategoryID, results ...dgkala.ProductSearchResult) Option {
+	return func(s *Server) {
+		for _, result := range results {
+			s.resultCategories[result.ID] = append(s.resultCategories[result.ID], categoryID)
+		}
+		s.searchResults = append(s.searchResults, results...)
+	}
+}
+
+// WithSuggestions sets the suggestions served by the autocomplete endpoint for a prefix.
+// The Image field of suggested products is served as their image path.
+// Other prefixes have no suggestions.
+func WithSuggestions(prefix string, suggestions dgkala.Suggestions) Option {
+	return func(s *Server) {
+		s.suggestions[prefix] = suggestions
+	}
+}
+
+// WithSearchFacets makes the search endpoint return facets as aggregations.
+// Without it search responses have no aggregations.
+func WithSearchFacets(facets dgkala.SearchFacets) Option {
+	return func(s *Server) {
+		s.searchFacets = &facets
+	}
+}
+
+// WithError makes an endpoint respond with statusCode
+func WithError(endpoint dgkala.Endpoint, statusCode int) Option {
+	return func(s *Server) {
+		s.errors[endpoint] = statusCode
+	}
+}
+
+// WithLatency delays every response of the server
+func WithLatency(latency time.Duration) Option {
+	return func(s *Server) {
+		s.latency = latency
+	}
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		products:    map[dgkala.ProductID]dgkala.ProductByID{},
+		comments:    map[dgkala.ProductID][]dgkala.Comment{},
+		questions:   map[dgkala.ProductID][]dgkala.Question{},
+		suggestions: map[string]dgkala.Suggestions{},
+		errors:      map[dgkala.Endpoint]int{},
+		requests:    map[dgkala.Endpoint]int{},
+
+		resultCategories: map[dgkala.ProductID][]dgkala.CategoryID{},
+	}
+	s.Configure(options...)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Configure applies options to a running server
+func (s *Server) Configure(options ...Option) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, option := range options {
+		option(s)
+	}
+}
+
+// ClearError makes an endpoint respond successfully again
+func (s *Server) ClearError(endpoint dgkala.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.errors, endpoint)
+}
+
+// Requests returns the number of requests received by an endpoint
+func (s *Server) Requests(endpoint dgkala.Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[endpoint]
+}
+
+// Client returns a dgkala.Client sending its requests to the server.
+// Options are applied after the server ones so they can override them.
+func (s *Server) Client(options ...dgkala.Option) *dgkala.Client {
+	serverOptions := []dgkala.Option{
+		dgkala.WithHTTPClient(s.Server.Client()),
+		dgkala.WithService2BaseURL(s.URL),
+		dgkala.WithSearchBaseURL(s.URL),
+		dgkala.WithFileBaseURL(s.URL),
+	}
+	return dgkala.NewClient(append(serverOptions, options...)...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var endpoint dgkala.Endpoint
+	switch {
+	case r.URL.Path == incredibleOffersPath:
+		endpoint = dgkala.EndpointIncredibleOffers
+	case r.URL.Path == searchPath:
+		endpoint = dgkala.EndpointSearch
+	case strings.HasPrefix(r.URL.Path, productByIDPath):
+		endpoint = dgkala.EndpointProductByID
+	case strings.HasPrefix(r.URL.Path, productCommentsPath):
+		endpoint = dgkala.EndpointProductComments
+	case strings.HasPrefix(r.URL.Path, productQuestionsPath):
+		endpoint = dgkala.EndpointProductQuestions
+	case r.URL.Path == categoriesPath:
+		endpoint = dgkala.EndpointCategories
+	case r.URL.Path == suggestPath:
+		endpoint = dgkala.EndpointSuggest
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests[endpoint]++
+	latency := s.latency
+	statusCode, failing := s.errors[endpoint]
+	s.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-r.Context().Done():
+			return
+		case <-timer.C:
+		}
+	}
+	if failing {
+		http.Error(w, http.StatusText(statusCode), statusCode)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch endpoint {
+	case dgkala.EndpointIncredibleOffers:
+		s.serveIncredibleOffers(w)
+	case dgkala.EndpointSearch:
+		s.serveSearch(w, r.URL.Query())
+	case dgkala.EndpointProductByID:
+		s.serveProductByID(w, strings.TrimPrefix(r.URL.Path, productByIDPath))
+	case dgkala.EndpointProductComments:
+		s.serveProductComments(w, strings.TrimPrefix(r.URL.Path, productCommentsPath), r.URL.Query())
+	case dgkala.EndpointProductQuestions:
+		s.serveProductQuestions(w, strings.TrimPrefix(r.URL.Path, productQuestionsPath), r.URL.Query())
+	case dgkala.EndpointCategories:
+		s.serveCategories(w)
+	case dgkala.EndpointSuggest:
+		s.serveSuggestions(w, r.URL.Query().Get("keyword"))
+	}
+}
+
+func (s *Server) serveIncredibleOffers(w http.ResponseWriter) {
+	offers := []interface{}{}
+	for _, offer := range s.offers {
+		offers = append(offers, withRaw(offer, offer.Raw))
+	}
+	writeJSON(w, map[string]interface{}{"Data": offers, "Status": "Ok"})
+}
+
+func (s *Server) serveProductByID(w http.ResponseWriter, rawID string) {
+	var ID dgkala.ProductID
+	if err := ID.UnmarshalText([]byte(rawID)); err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	product, ok := s.products[ID]
+	if !ok {
+		writeJSON(w, map[string]interface{}{"Data": nil, "Status": "Ok"})
+		return
+	}
+	writeJSON(w, map[string]interface{}{"Data": withRaw(product, product.Raw), "Status": "Ok"})
+}
+
+// productID parses a product ID path parameter of a known product
+func (s *Server) productID(rawID string) (dgkala.ProductID, bool) {
+	var ID dgkala.ProductID
+	if err := ID.UnmarshalText([]byte(rawID)); err != nil {
+		return 0, false
+	}
+	_, hasProduct := s.products[ID]
+	_, hasComments := s.comments[ID]
+	_, hasQuestions := s.questions[ID]
+	return ID, hasProduct || hasComments || hasQuestions
+}
+
+// pagination is a page of a paginated service2 endpoint
+type pagination struct {
+	number, count, start, end int
+}
+
+// paginate returns the page of total items requested by the pageno and pagesize parameters
+func paginate(query url.Values, total int) pagination {
+	number, err := strconv.Atoi(query.Get("pageno"))
+	if err != nil || number < 1 {
+		number = 1
+	}
+	size, err := strconv.Atoi(query.Get("pagesize"))
+	if err != nil || size < 1 {
+		size = defaultPageSize
+	}
+	start := (number - 1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	return pagination{number, (total + size - 1) / size, start, end}
+}
+
+func (s *Server) serveProductComments(w http.ResponseWriter, rawID string, query url.Values) {
+	ID, ok := s.productID(rawID)
+	if !ok {
+		writeJSON(w, map[string]interface{}{"Data": nil, "Status": "Ok"})
+		return
+	}
+
+	comments := append([]dgkala.Comment{}, s.comments[ID]...)
+	var less func(a, b dgkala.Comment) bool
+	switch query.Get("sort") {
+	case "most_helpful":
+		less = func(a, b dgkala.Comment) bool { return a.HelpfulVotes > b.HelpfulVotes }
+	case "rating_desc":
+		less = func(a, b dgkala.Comment) bool { return a.Rating > b.Rating }
+	case "rating_asc":
+		less = func(a, b dgkala.Comment) bool { return a.Rating < b.Rating }
+	default:
+		less = func(a, b dgkala.Comment) bool { return a.CreatedAt.After(b.CreatedAt.Time) }
+	}
+	sort.SliceStable(comments, func(i, j int) bool { return less(comments[i], comments[j]) })
+
+	page := paginate(query, len(comments))
+	writeJSON(w, map[string]interface{}{
+		"Data": map[string]interface{}{
+			"Comments":  comments[page.start:page.end],
+			"PageNo":    page.number,
+			"PageCount": page.count,
+			"Total":     len(comments),
+		},
+		"Status": "Ok",
+	})
+}
+
+func (s *Server) serveProductQuestions(w http.ResponseWriter, rawID string, query url.Values) {
+	ID, ok := s.productID(rawID)
+	if !ok {
+		writeJSON(w, map[string]interface{}{"Data": nil, "Status": "Ok"})
+		return
+	}
+
+	questions := s.questions[ID]
+	page := paginate(query, len(questions))
+	writeJSON(w, map[string]interface{}{
+		"Data": map[string]interface{}{
+			"Questions": questions[page.start:page.end],
+			"PageNo":    page.number,
+			"PageCount": page.count,
+			"Total":     len(questions),
+		},
+		"Status": "Ok",
+	})
+}
+
+func (s *Server) serveCategories(w http.ResponseWriter) {
+	categories := []dgkala.Category{}
+	var flatten func(parentID dgkala.CategoryID, nodes []dgkala.Category)
+	flatten = func(parentID dgkala.CategoryID, nodes []dgkala.Category) {
+		for _, category := range nodes {
+			children := category.Children
+			category.ParentID = parentID
+			category.Children = nil
+			categories = append(categories, category)
+			flatten(category.ID, children)
+		}
+	}
+	flatten(0, s.categories)
+	writeJSON(w, map[string]interface{}{"Data": categories, "Status": "Ok"})
+}
+
+func (s *Server) serveSuggestions(w http.ResponseWriter, prefix string) {
+	suggestions, ok := s.suggestions[prefix]
+	if !ok {
+		suggestions = dgkala.Suggestions{Keywords: []string{}, Categories: []dgkala.Category{}, Products: []dgkala.SuggestedProduct{}}
+	}
+	writeJSON(w, suggestions)
+}
+
+func (s *Server) serveSearch(w http.ResponseWriter, query url.Values) {
+	keyword := query.Get("keyword")
+	from, _ := strconv.Atoi(query.Get("from"))
+	size, err := strconv.Atoi(query.Get("size"))
+	if err != nil || size <= 0 {
+		size = defaultSearchSize
+	}
+
+	matches := []dgkala.ProductSearchResult{}
+	for _, result := range s.searchResults {
+		if matchesKeyword(result, keyword) && matchesFilters(result, query) && s.matchesCategory(result, query) {
+			matches = append(matches, result)
+		}
+	}
+	sortSearchResults(matches, query.Get("sort"))
+	hits := []interface{}{}
+	for i := from; i >= 0 && i < len(matches) && i < from+size; i++ {
+		hits = append(hits, map[string]interface{}{"_source": withRaw(searchHitSource(matches[i]), matches[i].Raw)})
+	}
+	response := map[string]interface{}{
+		"took": 1,
+		"hits": map[string]interface{}{
+			"total": len(matches),
+			"hits":  hits,
+		},
+	}
+	if s.searchFacets != nil {
+		response["aggregations"] = searchAggregations(*s.searchFacets)
+	}
+	writeJSON(w, response)
+}
+
+// searchAggregations encodes facets the way the search API does
+func searchAggregations(facets dgkala.SearchFacets) map[string]interface{} {
+	buckets := func(facetBuckets []dgkala.FacetBucket) map[string]interface{} {
+		encoded := []interface{}{}
+		for _, bucket := range facetBuckets {
+			encoded = append(encoded, map[string]interface{}{"key": bucket.Key, "doc_count": bucket.Count})
+		}
+		return map[string]interface{}{"buckets": encoded}
+	}
+	prices := []interface{}{}
+	for _, bucket := range facets.PriceRanges {
+		encoded := map[string]interface{}{"from": bucket.From, "doc_count": bucket.Count}
+		if !bucket.To.IsZero() {
+			encoded["to"] = bucket.To
+		}
+		prices = append(prices, encoded)
+	}
+	statuses := []interface{}{}
+	for _, bucket := range facets.Availability {
+		statuses = append(statuses, map[string]interface{}{"key": int(bucket.Status), "doc_count": bucket.Count})
+	}
+	return map[string]interface{}{
+		"brands": buckets(facets.Brands),
+		"colors": buckets(facets.Colors),
+		"prices": map[string]interface{}{"buckets": prices},
+		"status": map[string]interface{}{"buckets": statuses},
+	}
+}
+
+func matchesKeyword(result dgkala.ProductSearchResult, keyword string) bool {
+	keyword = strings.ToLower(keyword)
+	return strings.Contains(strings.ToLower(result.EnglishTitle), keyword) ||
+		strings.Contains(strings.ToLower(result.PersianTitle), keyword)
+}
+
+// matchesCategory reports whether a search result was added to the category of the query, if it has one
+func (s *Server) matchesCategory(result dgkala.ProductSearchResult, query url.Values) bool {
+	var categoryID dgkala.CategoryID
+	if err := categoryID.UnmarshalText([]byte(query.Get("category"))); err != nil {
+		return true
+	}
+	for _, ID := range s.resultCategories[result.ID] {
+		if ID == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFilters reports whether a search result passes the price, status, color, video and gift filters.
+// The brand filter is ignored as search results don't carry brands.
+func matchesFilters(result dgkala.ProductSearchResult, query url.Values) bool {
+	if minPrice, err := strconv.ParseInt(query.Get("minprice"), 10, 64); err == nil && result.MinimumPrice.Cmp(dgkala.Rials(minPrice)) < 0 {
+		return false
+	}
+	if maxPrice, err := strconv.ParseInt(query.Get("maxprice"), 10, 64); err == nil && result.MinimumPrice.Cmp(dgkala.Rials(maxPrice)) > 0 {
+		return false
+	}
+	if status, err := strconv.Atoi(query.Get("status")); err == nil && int(result.ExistsStatus) != status {
+		return false
+	}
+	if query.Get("hasvideo") == "true" && !result.HasVideo {
+		return false
+	}
+	if query.Get("hasgift") == "true" && !result.HasGift {
+		return false
+	}
+	if colors, ok := query["color"]; ok {
+		for _, code := range colors {
+			for _, color := range result.Colors {
+				if color.Code == code {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// sortSearchResults sorts search results by a search API sort parameter
+func sortSearchResults(results []dgkala.ProductSearchResult, sortParameter string) {
+	var less func(a, b dgkala.ProductSearchResult) bool
+	switch sortParameter {
+	case "MinPrice:asc":
+		less = func(a, b dgkala.ProductSearchResult) bool { return a.MinimumPrice.Cmp(b.MinimumPrice) < 0 }
+	case "MinPrice:desc":
+		less = func(a, b dgkala.ProductSearchResult) bool { return a.MinimumPrice.Cmp(b.MinimumPrice) > 0 }
+	case "RegDateTime:desc":
+		less = func(a, b dgkala.ProductSearchResult) bool { return a.RegisteredDateTime.After(b.RegisteredDateTime) }
+	case "ViewCounter:desc":
+		less = func(a, b dgkala.ProductSearchResult) bool { return a.Views > b.Views }
+	case "LastPeriodSaleCounter:desc":
+		less = func(a, b dgkala.ProductSearchResult) bool { return a.LastPeriodSales > b.LastPeriodSales }
+	default:
+		return
+	}
+	sort.SliceStable(results, func(i, j int) bool { return less(results[i], results[j]) })
+}
+
+// searchHitSource encodes a search result the way the search API does
+func searchHitSource(result dgkala.ProductSearchResult) map[string]interface{} {
+	colors := []interface{}{}
+	for _, color := range result.Colors {
+		colors = append(colors, map[string]interface{}{
+			"ColorTitle": color.Title,
+			"ColorHex":   color.Hex,
+			"ColorCode":  color.Code,
+		})
+	}
+	return map[string]interface{}{
+		"Id":                        result.ID,
+		"EnTitle":                   result.EnglishTitle,
+		"FaTitle":                   result.PersianTitle,
+		"ImagePath":                 result.Image,
+		"ExistStatus":               int(result.ExistsStatus),
+		"IsActive":                  result.IsActive,
+		"UrlCode":                   result.URL,
+		"Rate":                      result.Rate,
+		"MinPrice":                  result.MinimumPrice,
+		"MaxPrice":                  result.MaximumPrice,
+		"LikeCounter":               result.Likes,
+		"LastPeriodLikeCounter":     result.LastPeriodLikes,
+		"ViewCounter":               result.Views,
+		"LastPeriodViewCounter":     result.LastPeriodViews,
+		"IsSpecialOffer":            result.IsSpecialOffer,
+		"RegDateTime":               result.RegisteredDateTime.In(dgkala.TehranLocation()).Format("2006-01-02T15:04:05"),
+		"HasVideo":                  result.HasVideo,
+		"ProductColorList":          colors,
+		"UserRating":                result.UserRatingCount,
+		"FavoriteCounter":           result.Favorites,
+		"LastPeriodFavoriteCounter": result.LastPeriodFavorites,
+		"LastPeriodSaleCounter":     result.LastPeriodSales,
+		"HasGift":                   result.HasGift,
+		"DetailSource":              result.HTMLDetails,
+	}
+}
+
+// withRaw encodes a fixture along with the fields of its raw JSON which it doesn't have,
+// so fixtures can contain fields which are not modelled by dgkala
+func withRaw(value interface{}, raw dgkala.RawJSON) interface{} {
+	if len(raw) == 0 {
+		return value
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return value
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return value
+	}
+	return fields
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,44 @@
+package dgkalatest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mamal72/dgkala"
+)
+
+func TestServer_errors(t *testing.T) {
+	server := NewServer(WithError(dgkala.EndpointProductByID, http.StatusServiceUnavailable))
+	defer server.Close()
+	client := server.Client()
+
+	_, err := client.GetProductByID(context.Background(), 1)
+	var httpErr *dgkala.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("GetProductByID() error = %v, want status %v", err, http.StatusServiceUnavailable)
+	}
+
+	server.ClearError(dgkala.EndpointProductByID)
+	server.Configure(WithProducts(dgkala.ProductByID{ID: 1}))
+	if _, err := client.GetProductByID(context.Background(), 1); err != nil {
+		t.Errorf("GetProductByID() error = %v", err)
+	}
+	if got := server.Requests(dgkala.EndpointProductByID); got != 2 {
+		t.Errorf("Requests() = %v, want 2", got)
+	}
+}
+
+func TestServer_latency(t *testing.T) {
+	server := NewServer(WithLatency(time.Second))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := server.Client().IncredibleOffers(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("IncredibleOffers() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,59 @@
+package dgkala
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotFound is returned when the requested resource doesn't exist.
+// It matches 404 HTTPErrors too, so errors.Is(err, ErrNotFound) is the way to check for it.
+var ErrNotFound = errors.New("dgkala: not found")
+
+// maxErrorBodySize is the maximum number of response body bytes kept in an HTTPError
+const maxErrorBodySize = 512
+
+// HTTPError is returned when DGKala responds with a non 2xx status code
+type HTTPError struct {
+	StatusCode int
+	URL        string
+	// Body is a snippet of the response body
+	Body string
+	// RetryAfter is the parsed Retry-After header, zero if it's missing
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("dgkala: GET %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether the error matches target.
+// A 404 HTTPError matches ErrNotFound.
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// APIError is returned when DGKala responds with a non successful Status field
+type APIError struct {
+	Status string
+	URL    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("dgkala: GET %s: API status %q", e.URL, e.Status)
+}
+
+// DecodeError is returned when a DGKala response can't be decoded
+type DecodeError struct {
+	URL string
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("dgkala: GET %s: decode response: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,98 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		call       func(c *Client) error
+		check      func(err error) bool
+	}{
+		{
+			name:       "Test should return HTTPError for 5xx responses",
+			statusCode: http.StatusBadGateway,
+			body:       "bad gateway",
+			call: func(c *Client) error {
+				_, err := c.IncredibleOffers(context.Background())
+				return err
+			},
+			check: func(err error) bool {
+				var httpErr *HTTPError
+				return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusBadGateway &&
+					httpErr.Body == "bad gateway" && !errors.Is(err, ErrNotFound)
+			},
+		},
+		{
+			name:       "Test should match ErrNotFound for 404 responses",
+			statusCode: http.StatusNotFound,
+			call: func(c *Client) error {
+				_, err := c.GetProductByID(context.Background(), 1)
+				return err
+			},
+			check: func(err error) bool {
+				return errors.Is(err, ErrNotFound)
+			},
+		},
+		{
+			name:       "Test should return ErrNotFound for empty product data",
+			statusCode: http.StatusOK,
+			body:       `{"Data":null,"Status":"Ok"}`,
+			call: func(c *Client) error {
+				_, err := c.GetProductByID(context.Background(), 1)
+				return err
+			},
+			check: func(err error) bool {
+				return errors.Is(err, ErrNotFound)
+			},
+		},
+		{
+			name:       "Test should return APIError for failed statuses",
+			statusCode: http.StatusOK,
+			body:       `{"Data":[],"Status":"Error"}`,
+			call: func(c *Client) error {
+				_, err := c.IncredibleOffers(context.Background())
+				return err
+			},
+			check: func(err error) bool {
+				var apiErr *APIError
+				return errors.As(err, &apiErr) && apiErr.Status == "Error"
+			},
+		},
+		{
+			name:       "Test should return DecodeError for malformed responses",
+			statusCode: http.StatusOK,
+			body:       `{"took":`,
+			call: func(c *Client) error {
+				_, err := c.Search(context.Background(), "keyword")
+				return err
+			},
+			check: func(err error) bool {
+				var decodeErr *DecodeError
+				return errors.As(err, &decodeErr)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithService2BaseURL(server.URL), WithSearchBaseURL(server.URL))
+			err := tt.call(client)
+			if !tt.check(err) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package dgkala
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/buger/jsonparser"
+)
+
+// FacetBucket is the number of search results having a value
+type FacetBucket struct {
+	Key   string
+	Count int64
+}
+
+// PriceBucket is the number of search results with a minimum price in [From, To).
+// Zero To means no upper bound.
+type PriceBucket struct {
+	From  Money
+	To    Money
+	Count int64
+}
+
+// AvailabilityBucket is the number of search results having an exists status
+type AvailabilityBucket struct {
+	Status ProductExistsStatus
+	Count  int64
+}
+
+// SearchFacets contains the facet counts of search results
+type SearchFacets struct {
+	Brands       []FacetBucket
+	Colors       []FacetBucket
+	PriceRanges  []PriceBucket
+	Availability []AvailabilityBucket
+	// Local is true if the search API didn't return aggregations
+	// and the facets are computed from the returned results only.
+	// Brands are not available in local facets.
+	Local bool
+}
+
+// localPriceRanges are the boundaries of price buckets of local facets
+var localPriceRanges = []Money{Rials(0), Rials(1000000), Rials(5000000), Rials(10000000), Rials(50000000)}
+
+// aggregationsHit is the FieldProblem.Hit of problems of search aggregations
+const aggregationsHit = -1
+
+// parseSearchFacets parses the aggregations of a search response.
+// It returns false if the response has no aggregations, and the problems of malformed aggregations.
+func parseSearchFacets(body []byte) (SearchFacets, bool, []FieldProblem) {
+	aggregations, dataType, _, err := jsonparser.Get(body, "aggregations")
+	if err == jsonparser.KeyPathNotFoundError || dataType == jsonparser.Null {
+		return SearchFacets{}, false, nil
+	}
+	if err != nil {
+		return SearchFacets{}, false, []FieldProblem{{aggregationsHit, "aggregations", err}}
+	}
+
+	facets := SearchFacets{
+		Brands:       []FacetBucket{},
+		Colors:       []FacetBucket{},
+		PriceRanges:  []PriceBucket{},
+		Availability: []AvailabilityBucket{},
+	}
+	problems := []FieldProblem{}
+	eachBucket := func(name string, callback func(bucket *fieldDecoder, count int64)) {
+		index := 0
+		err := jsonparser.ArrayEach(aggregations, func(value []byte, _ jsonparser.ValueType, _ int, _ error) {
+			bucket := &fieldDecoder{
+				value:    value,
+				hit:      aggregationsHit,
+				prefix:   fmt.Sprintf("aggregations.%s.buckets[%d].", name, index),
+				problems: &problems,
+			}
+			index++
+			callback(bucket, bucket.getInt("doc_count"))
+		}, name, "buckets")
+		if err != nil && err != jsonparser.KeyPathNotFoundError {
+			problems = append(problems, FieldProblem{aggregationsHit, "aggregations." + name + ".buckets", err})
+		}
+	}
+
+	eachBucket("brands", func(bucket *fieldDecoder, count int64) {
+		facets.Brands = append(facets.Brands, FacetBucket{bucketKey(bucket), count})
+	})
+	eachBucket("colors", func(bucket *fieldDecoder, count int64) {
+		facets.Colors = append(facets.Colors, FacetBucket{bucketKey(bucket), count})
+	})
+	eachBucket("prices", func(bucket *fieldDecoder, count int64) {
+		from := bucketBound(bucket, "from")
+		to := bucketBound(bucket, "to")
+		facets.PriceRanges = append(facets.PriceRanges, PriceBucket{from, to, count})
+	})
+	eachBucket("status", func(bucket *fieldDecoder, count int64) {
+		key := bucketKey(bucket)
+		status, err := strconv.Atoi(key)
+		if err != nil {
+			bucket.report("key", fmt.Errorf("%w: %v", ErrFieldType, err))
+		}
+		facets.Availability = append(facets.Availability, AvailabilityBucket{ProductExistsStatus(status), count})
+	})
+	return facets, true, problems
+}
+
+// bucketKey returns the key of an aggregation bucket, which is a string or a number
+func bucketKey(bucket *fieldDecoder) string {
+	raw, dataType, _, err := jsonparser.Get(bucket.value, "key")
+	switch {
+	case err == jsonparser.KeyPathNotFoundError || dataType == jsonparser.Null:
+		bucket.report("key", ErrMissingField)
+	case err != nil:
+		bucket.report("key", err)
+	case dataType == jsonparser.String:
+		key, err := jsonparser.ParseString(raw)
+		if err != nil {
+			bucket.report("key", fmt.Errorf("%w: %v", ErrFieldType, err))
+		}
+		return key
+	case dataType == jsonparser.Number:
+		return string(raw)
+	default:
+		bucket.report("key", fmt.Errorf("%w: got %s, want %s or %s", ErrFieldType, dataType, jsonparser.String, jsonparser.Number))
+	}
+	return ""
+}
+
+// bucketBound returns a bound of a price aggregation bucket. Missing bounds are zero.
+func bucketBound(bucket *fieldDecoder, field string) Money {
+	raw, dataType, _, err := jsonparser.Get(bucket.value, field)
+	switch {
+	case err == jsonparser.KeyPathNotFoundError || dataType == jsonparser.Null:
+		return Money{}
+	case err != nil:
+		bucket.report(field, err)
+		return Money{}
+	case dataType != jsonparser.Number:
+		bucket.report(field, fmt.Errorf("%w: got %s, want %s", ErrFieldType, dataType, jsonparser.Number))
+		return Money{}
+	}
+	value, err := jsonparser.ParseFloat(raw)
+	if err != nil {
+		bucket.report(field, fmt.Errorf("%w: %v", ErrFieldType, err))
+	}
+	return Rials(int64(value))
+}
+
+// localSearchFacets computes facets from search results
+func localSearchFacets(results []ProductSearchResult) SearchFacets {
+	colorCounts := map[string]int64{}
+	statusCounts := map[ProductExistsStatus]int64{}
+	priceCounts := make([]int64, len(localPriceRanges))
+	for _, result := range results {
+		for _, color := range result.Colors {
+			colorCounts[color.Code]++
+		}
+		statusCounts[result.ExistsStatus]++
+		for i := len(localPriceRanges) - 1; i >= 0; i-- {
+			if result.MinimumPrice.Cmp(localPriceRanges[i]) >= 0 {
+				priceCounts[i]++
+				break
+			}
+		}
+	}
+
+	facets := SearchFacets{
+		Brands:       []FacetBucket{},
+		Colors:       []FacetBucket{},
+		PriceRanges:  []PriceBucket{},
+		Availability: []AvailabilityBucket{},
+		Local:        true,
+	}
+	for code, count := range colorCounts {
+		facets.Colors = append(facets.Colors, FacetBucket{code, count})
+	}
+	sort.Slice(facets.Colors, func(i, j int) bool {
+		a, b := facets.Colors[i], facets.Colors[j]
+		return a.Count > b.Count || (a.Count == b.Count && a.Key < b.Key)
+	})
+	for status, count := range statusCounts {
+		facets.Availability = append(facets.Availability, AvailabilityBucket{status, count})
+	}
+	sort.Slice(facets.Availability, func(i, j int) bool {
+		return facets.Availability[i].Status < facets.Availability[j].Status
+	})
+	for i, count := range priceCounts {
+		if count == 0 {
+			continue
+		}
+		bucket := PriceBucket{From: localPriceRanges[i], Count: count}
+		if i+1 < len(localPriceRanges) {
+			bucket.To = localPriceRanges[i+1]
+		}
+		facets.PriceRanges = append(facets.PriceRanges, bucket)
+	}
+	return facets
+}
@@ -0,0 +1,90 @@
+package dgkala
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseSearchFacets(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		want         SearchFacets
+		wantOK       bool
+		wantProblems []string
+	}{
+		{
+			name:   "Test should report missing aggregations",
+			body:   `{"took":1,"hits":{"total":0,"hits":[]}}`,
+			wantOK: false,
+		},
+		{
+			name: "Test should parse aggregations",
+			body: `{"aggregations":{
+				"brands":{"buckets":[{"key":"Samsung","doc_count":12},{"key":"\u0627\u0644 \u062c\u06cc","doc_count":3}]},
+				"colors":{"buckets":[{"key":"black","doc_count":7}]},
+				"prices":{"buckets":[{"from":0,"to":1000000,"doc_count":4},{"from":1000000,"doc_count":11}]},
+				"status":{"buckets":[{"key":2,"doc_count":14},{"key":"3","doc_count":1}]}
+			}}`,
+			want: SearchFacets{
+				Brands:       []FacetBucket{{"Samsung", 12}, {"ال جی", 3}},
+				Colors:       []FacetBucket{{"black", 7}},
+				PriceRanges:  []PriceBucket{{Rials(0), Rials(1000000), 4}, {Rials(1000000), Rials(0), 11}},
+				Availability: []AvailabilityBucket{{Available, 14}, {OutOfStock, 1}},
+			},
+			wantOK: true,
+		},
+		{
+			name: "Test should report malformed buckets",
+			body: `{"aggregations":{
+				"brands":{"buckets":[{"doc_count":1}]},
+				"prices":{"buckets":[{"from":"cheap","doc_count":1}]},
+				"status":{"buckets":[{"key":"unknown","doc_count":"1"}]}
+			}}`,
+			wantOK: true,
+			wantProblems: []string{
+				"aggregations.brands.buckets[0].key",
+				"aggregations.prices.buckets[0].from",
+				"aggregations.status.buckets[0].doc_count",
+				"aggregations.status.buckets[0].key",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, problems := parseSearchFacets([]byte(tt.body))
+			if ok != tt.wantOK {
+				t.Errorf("parseSearchFacets() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if len(problems) != len(tt.wantProblems) {
+				t.Fatalf("parseSearchFacets() problems = %v, want %v", problems, tt.wantProblems)
+			}
+			for i, problem := range problems {
+				if problem.Hit != aggregationsHit || problem.Field != tt.wantProblems[i] {
+					t.Errorf("parseSearchFacets() problem %d = %v, want %s", i, problem, tt.wantProblems[i])
+				}
+			}
+			if len(tt.wantProblems) == 0 && tt.wantOK && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSearchFacets() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_localSearchFacets(t *testing.T) {
+	results := []ProductSearchResult{
+		{ExistsStatus: Available, MinimumPrice: Rials(500000), Colors: []ProductColor{{Code: "black"}, {Code: "white"}}},
+		{ExistsStatus: Available, MinimumPrice: Rials(2000000), Colors: []ProductColor{{Code: "black"}}},
+		{ExistsStatus: OutOfStock, MinimumPrice: Rials(60000000)},
+	}
+	want := SearchFacets{
+		Brands:       []FacetBucket{},
+		Colors:       []FacetBucket{{"black", 2}, {"white", 1}},
+		PriceRanges:  []PriceBucket{{Rials(0), Rials(1000000), 1}, {Rials(1000000), Rials(5000000), 1}, {Rials(50000000), Rials(0), 1}},
+		Availability: []AvailabilityBucket{{Available, 2}, {OutOfStock, 1}},
+		Local:        true,
+	}
+	if got := localSearchFacets(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("localSearchFacets() = %+v, want %+v", got, want)
+	}
+}
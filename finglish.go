@@ -0,0 +1,67 @@
+package dgkala
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mamal72/dgkala/finglish"
+)
+
+// DefaultFinglishCandidates is the number of Persian spellings searched by SearchFinglish if FinglishOptions.Candidates isn't set
+const DefaultFinglishCandidates = 3
+
+// FinglishOptions configures SearchFinglish
+type FinglishOptions struct {
+	// Candidates is the maximum number of Persian spellings searched
+	Candidates int
+	// SearchOptions is the page of results requested for each spelling
+	SearchOptions SearchOptions
+}
+
+// RankedSearchResult is a product found by SearchFinglish
+type RankedSearchResult struct {
+	Product ProductSearchResult
+	// Keyword is the Persian spelling which matched the product best
+	Keyword string
+	// Score is the match quality of the product. Each spelling which found the product
+	// adds its transliteration score divided by the rank of the product in its results.
+	Score float64
+}
+
+// SearchFinglish searches for the Persian spellings of a Finglish text, like "goushi samsung",
+// and merges their results. Every product is returned once and the best matches come first.
+func (c *Client) SearchFinglish(ctx context.Context, text string, options FinglishOptions) ([]RankedSearchResult, error) {
+	limit := options.Candidates
+	if limit < 1 {
+		limit = DefaultFinglishCandidates
+	}
+
+	ranked := []RankedSearchResult{}
+	index := map[ProductID]int{}
+	best := map[ProductID]float64{}
+	for _, candidate := range finglish.Transliterate(text, limit) {
+		result, err := c.SearchWithOptions(ctx, candidate.Text, options.SearchOptions)
+		if err != nil {
+			return nil, err
+		}
+		for rank, product := range result.Results {
+			score := candidate.Score / float64(rank+1)
+			i, ok := index[product.ID]
+			if !ok {
+				i = len(ranked)
+				index[product.ID] = i
+				ranked = append(ranked, RankedSearchResult{Product: product})
+			}
+			ranked[i].Score += score
+			if score > best[product.ID] {
+				best[product.ID] = score
+				ranked[i].Keyword = candidate.Text
+			}
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked, nil
+}
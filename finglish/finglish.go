@@ -0,0 +1,203 @@
+// Package finglish transliterates Finglish, Persian written in Latin letters like "goushi samsung",
+// to candidate Persian spellings like "گوشی سامسونگ".
+//
+// Finglish has no standard spelling and Persian doesn't write most short vowels,
+// so every text has several possible spellings. They are ranked by a score which is
+// the product of the likelihoods of the letters chosen for each part of the text.
+package finglish
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Candidate is a Persian spelling of a Finglish text
+type Candidate struct {
+	Text string
+	// Score is the likelihood of the spelling, between 0 and 1
+	Score float64
+}
+
+// option is a Persian spelling of a Latin letter group with its likelihood
+type option struct {
+	persian string
+	weight  float64
+}
+
+// rule is the Persian spellings of a Latin letter group at the start, in the middle and at the end of a word
+type rule struct {
+	initial, medial, final []option
+}
+
+func same(options ...option) rule {
+	return rule{options, options, options}
+}
+
+// rules are the spellings of Latin letter groups. Longer groups take precedence.
+var rules = map[string]rule{
+	"aa": {initial: []option{{"آ", 1}}, medial: []option{{"ا", 1}}, final: []option{{"ا", 1}}},
+	"a":  {initial: []option{{"ا", 0.6}, {"آ", 0.4}}, medial: []option{{"ا", 0.6}, {"", 0.4}}, final: []option{{"ه", 0.5}, {"ا", 0.5}}},
+	"e":  {initial: []option{{"ا", 1}}, medial: []option{{"", 0.8}, {"ی", 0.2}}, final: []option{{"ه", 0.7}, {"", 0.3}}},
+	"o":  {initial: []option{{"ا", 0.6}, {"او", 0.4}}, medial: []option{{"و", 0.5}, {"", 0.5}}, final: []option{{"و", 1}}},
+	"ou": {initial: []option{{"او", 1}}, medial: []option{{"و", 1}}, final: []option{{"و", 1}}},
+	"oo": {initial: []option{{"او", 1}}, medial: []option{{"و", 1}}, final: []option{{"و", 1}}},
+	"u":  {initial: []option{{"او", 1}}, medial: []option{{"و", 1}}, final: []option{{"و", 1}}},
+	"i":  {initial: []option{{"ای", 1}}, medial: []option{{"ی", 1}}, final: []option{{"ی", 1}}},
+	"ee": {initial: []option{{"ای", 1}}, medial: []option{{"ی", 1}}, final: []option{{"ی", 1}}},
+	"ei": {initial: []option{{"ای", 1}}, medial: []option{{"ی", 1}}, final: []option{{"ی", 1}}},
+	"ey": {initial: []option{{"ای", 1}}, medial: []option{{"ی", 1}}, final: []option{{"ی", 1}}},
+	"kh": same(option{"خ", 1}),
+	"sh": same(option{"ش", 1}),
+	"ch": same(option{"چ", 1}),
+	"zh": same(option{"ژ", 1}),
+	"gh": same(option{"ق", 0.6}, option{"غ", 0.4}),
+	"b":  same(option{"ب", 1}),
+	"p":  same(option{"پ", 1}),
+	"t":  same(option{"ت", 0.85}, option{"ط", 0.15}),
+	"s":  same(option{"س", 0.85}, option{"ص", 0.1}, option{"ث", 0.05}),
+	"j":  same(option{"ج", 1}),
+	"h":  same(option{"ه", 0.8}, option{"ح", 0.2}),
+	"d":  same(option{"د", 1}),
+	"z":  same(option{"ز", 0.85}, option{"ض", 0.05}, option{"ظ", 0.05}, option{"ذ", 0.05}),
+	"r":  same(option{"ر", 1}),
+	"f":  same(option{"ف", 1}),
+	"k":  same(option{"ک", 1}),
+	"c":  same(option{"ک", 0.7}, option{"س", 0.3}),
+	"g":  same(option{"گ", 1}),
+	"l":  same(option{"ل", 1}),
+	"m":  same(option{"م", 1}),
+	"n":  same(option{"ن", 1}),
+	"v":  same(option{"و", 1}),
+	"w":  same(option{"و", 1}),
+	"y":  same(option{"ی", 1}),
+	"q":  same(option{"ق", 1}),
+	"x":  same(option{"کس", 1}),
+	"'":  same(option{"ع", 0.7}, option{"ئ", 0.3}),
+}
+
+// maxRuleLength is the length of the longest Latin letter group of rules
+const maxRuleLength = 2
+
+// Transliterate returns up to limit Persian spellings of a Finglish text, the most likely first.
+// Values of limit lower than 1 mean 1. Words having digits or non Latin letters, like "s21", are kept as they are.
+func Transliterate(text string, limit int) []Candidate {
+	if limit < 1 {
+		limit = 1
+	}
+	candidates := []Candidate{{Text: "", Score: 1}}
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		wordCandidates := transliterateWord(word, limit)
+		var combined []Candidate
+		for _, candidate := range candidates {
+			for _, wordCandidate := range wordCandidates {
+				text := wordCandidate.Text
+				if candidate.Text != "" {
+					text = candidate.Text + " " + text
+				}
+				combined = append(combined, Candidate{Text: text, Score: candidate.Score * wordCandidate.Score})
+			}
+		}
+		candidates = best(combined, limit)
+	}
+	if len(candidates) == 1 && candidates[0].Text == "" {
+		return []Candidate{}
+	}
+	return candidates
+}
+
+// transliterateWord returns up to limit Persian spellings of a word
+func transliterateWord(word string, limit int) []Candidate {
+	if !isFinglish(word) {
+		return []Candidate{{Text: word, Score: 1}}
+	}
+	word = collapseDoubles(word)
+
+	// beams[i] are the best spellings of the first i bytes of the word
+	beams := make([][]Candidate, len(word)+1)
+	beams[0] = []Candidate{{Text: "", Score: 1}}
+	for i := 0; i < len(word); i++ {
+		if len(beams[i]) == 0 {
+			continue
+		}
+		group, rule, ok := match(word, i)
+		if !ok {
+			// unknown characters are skipped
+			beams[i+1] = best(append(beams[i+1], beams[i]...), limit)
+			continue
+		}
+		options := rule.medial
+		switch {
+		case i == 0:
+			options = rule.initial
+		case i+len(group) == len(word):
+			options = rule.final
+		}
+		next := i + len(group)
+		for _, candidate := range beams[i] {
+			for _, option := range options {
+				beams[next] = append(beams[next], Candidate{Text: candidate.Text + option.persian, Score: candidate.Score * option.weight})
+			}
+		}
+		beams[next] = best(beams[next], limit)
+	}
+	return beams[len(word)]
+}
+
+// match returns the longest Latin letter group of rules at index i of word
+func match(word string, i int) (string, rule, bool) {
+	for length := maxRuleLength; length > 0; length-- {
+		if i+length > len(word) {
+			continue
+		}
+		if rule, ok := rules[word[i:i+length]]; ok {
+			return word[i : i+length], rule, true
+		}
+	}
+	return "", rule{}, false
+}
+
+// isFinglish reports whether a word is made of Latin letters and apostrophes only
+func isFinglish(word string) bool {
+	for _, r := range word {
+		if (r < 'a' || r > 'z') && r != '\'' {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// collapseDoubles replaces doubled consonants with one, as Persian doesn't write them twice
+func collapseDoubles(word string) string {
+	var collapsed strings.Builder
+	for i := 0; i < len(word); i++ {
+		if i > 0 && word[i] == word[i-1] && !strings.ContainsRune("aeiou", rune(word[i])) && unicode.IsLetter(rune(word[i])) {
+			continue
+		}
+		collapsed.WriteByte(word[i])
+	}
+	return collapsed.String()
+}
+
+// best returns up to limit candidates with the highest scores, without duplicate texts
+func best(candidates []Candidate, limit int) []Candidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Text < candidates[j].Text
+	})
+	seen := map[string]bool{}
+	result := make([]Candidate, 0, limit)
+	for _, candidate := range candidates {
+		if seen[candidate.Text] {
+			continue
+		}
+		seen[candidate.Text] = true
+		result = append(result, candidate)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package finglish
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		want     string
+		wantBest bool
+	}{
+		{"Test should transliterate words", "goushi samsung", "گوشی سامسونگ", true},
+		{"Test should drop short vowels", "ketab", "کتاب", true},
+		{"Test should ignore case", "Goushi", "گوشی", true},
+		{"Test should keep Persian words", "goushi سامسونگ", "گوشی سامسونگ", true},
+		{"Test should transliterate digraphs", "kharid shoma", "خرید شما", false},
+		{"Test should spell initial vowels", "ab", "آب", false},
+		{"Test should collapse doubled consonants", "kollah", "کلاه", false},
+		{"Test should keep words with digits", "galaxy s21", "گلکسی s21", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Transliterate(tt.text, 5)
+			found := false
+			for i, candidate := range got {
+				if candidate.Text == tt.want {
+					found = !tt.wantBest || i == 0
+				}
+				if i > 0 && candidate.Score > got[i-1].Score {
+					t.Errorf("Transliterate(%q) = %v, want candidates sorted by score", tt.text, got)
+				}
+			}
+			if !found {
+				t.Errorf("Transliterate(%q) = %v, want %q (first: %v)", tt.text, got, tt.want, tt.wantBest)
+			}
+		})
+	}
+}
+
+func TestTransliterateLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  int
+	}{
+		{"Test should return up to limit candidates", "samsung", 3, 3},
+		{"Test should return one candidate for limits lower than 1", "samsung", 0, 1},
+		{"Test should return no candidates for empty texts", "  ", 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Transliterate(tt.text, tt.limit); len(got) != tt.want {
+				t.Errorf("Transliterate() = %v, want %v candidates", got, tt.want)
+			}
+		})
+	}
+}
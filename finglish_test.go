@@ -0,0 +1,54 @@
+package dgkala_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/mamal72/dgkala"
+	"github.com/mamal72/dgkala/dgkalatest"
+)
+
+func TestClient_SearchFinglish(t *testing.T) {
+	server := dgkalatest.NewServer(dgkalatest.WithSearchResults(
+		dgkala.ProductSearchResult{ID: 4, PersianTitle: "قاب سامسونگ (سمسونگ)"},
+		dgkala.ProductSearchResult{ID: 1, PersianTitle: "تبلت سمسونگ"},
+		dgkala.ProductSearchResult{ID: 2, PersianTitle: "گوشی سامسونگ"},
+		dgkala.ProductSearchResult{ID: 3, PersianTitle: "گوشی شیائومی"},
+	))
+	defer server.Close()
+	client := server.Client()
+
+	got, err := client.SearchFinglish(context.Background(), "samsung", dgkala.FinglishOptions{})
+	if err != nil {
+		t.Fatalf("SearchFinglish() error = %v", err)
+	}
+
+	var IDs []dgkala.ProductID
+	var keywords []string
+	for _, result := range got {
+		IDs = append(IDs, result.Product.ID)
+		keywords = append(keywords, result.Keyword)
+	}
+	// product 4 is found by both spellings and product 2 by the most likely spelling only
+	if want := []dgkala.ProductID{4, 2, 1}; !reflect.DeepEqual(IDs, want) {
+		t.Errorf("SearchFinglish() IDs = %v, want %v", IDs, want)
+	}
+	if want := []string{"سامسونگ", "سامسونگ", "سمسونگ"}; !reflect.DeepEqual(keywords, want) {
+		t.Errorf("SearchFinglish() keywords = %v, want %v", keywords, want)
+	}
+	if got := server.Requests(dgkala.EndpointSearch); got != dgkala.DefaultFinglishCandidates {
+		t.Errorf("sent %v search requests, want %v", got, dgkala.DefaultFinglishCandidates)
+	}
+}
+
+func TestClient_SearchFinglishError(t *testing.T) {
+	server := dgkalatest.NewServer(dgkalatest.WithError(dgkala.EndpointSearch, http.StatusInternalServerError))
+	defer server.Close()
+
+	got, err := server.Client().SearchFinglish(context.Background(), "goushi", dgkala.FinglishOptions{})
+	if err == nil || got != nil {
+		t.Errorf("SearchFinglish() = %v, %v, want an error", got, err)
+	}
+}
@@ -0,0 +1,302 @@
+package dgkala
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidID is matched by errors returned when parsing an invalid ID
+var ErrInvalidID = errors.New("dgkala: invalid ID")
+
+// ProductID is the ID of a DGKala product
+type ProductID int64
+
+// OfferID is the ID of an incredible offer
+type OfferID int64
+
+// CategoryID is the ID of a product category
+type CategoryID int64
+
+// BrandID is the ID of a product brand
+type BrandID int64
+
+// VariantID is the ID of a product variant
+type VariantID int64
+
+// SellerID is the ID of a seller
+type SellerID int64
+
+// CommentID is the ID of a product comment
+type CommentID int64
+
+// QuestionID is the ID of a product question
+type QuestionID int64
+
+// AnswerID is the ID of an answer to a product question
+type AnswerID int64
+
+// productCodePattern matches product codes like dkp-12345 which are used in product URLs
+var productCodePattern = regexp.MustCompile(`(?i)\bdkp-(\d+)`)
+
+// ParseProductID parses a product ID from a number, a product code like dkp-12345
+// or a product URL like https://www.digikala.com/product/dkp-12345/title.
+// Unlike decoding, it fails for zero as zero is not the ID of any product.
+func ParseProductID(s string) (ProductID, error) {
+	var ID ProductID
+	if err := ID.UnmarshalText([]byte(s)); err != nil {
+		return 0, err
+	}
+	if ID == 0 {
+		return 0, fmt.Errorf("dgkala: parse ID %q: %w", s, ErrInvalidID)
+	}
+	return ID, nil
+}
+
+func (id ProductID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// Code returns the product code used in DGKala URLs, like dkp-12345
+func (id ProductID) Code() string {
+	return "dkp-" + id.String()
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id ProductID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *ProductID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id ProductID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number, a product code or a product URL
+func (id *ProductID) UnmarshalText(text []byte) error {
+	if match := productCodePattern.FindSubmatch(text); match != nil {
+		text = match[1]
+	}
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id OfferID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id OfferID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *OfferID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id OfferID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *OfferID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id CategoryID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id CategoryID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *CategoryID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id CategoryID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *CategoryID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id BrandID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id BrandID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *BrandID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id BrandID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *BrandID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id VariantID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id VariantID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *VariantID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id VariantID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *VariantID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id SellerID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id SellerID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *SellerID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id SellerID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *SellerID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id CommentID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id CommentID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *CommentID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id CommentID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *CommentID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id QuestionID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id QuestionID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *QuestionID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id QuestionID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *QuestionID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+func (id AnswerID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a JSON number
+func (id AnswerID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalJSON decodes the ID from a JSON number or string
+func (id *AnswerID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONID((*int64)(id), data)
+}
+
+// MarshalText encodes the ID as a decimal number
+func (id AnswerID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText decodes the ID from a decimal number
+func (id *AnswerID) UnmarshalText(text []byte) error {
+	return unmarshalTextID((*int64)(id), text)
+}
+
+// unmarshalJSONID decodes an ID from a JSON number or string. null leaves the ID unchanged.
+func unmarshalJSONID(id *int64, data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	return unmarshalTextID(id, []byte(text))
+}
+
+// unmarshalTextID decodes an ID from a decimal number. IDs can't be negative and zero means no ID.
+func unmarshalTextID(id *int64, text []byte) error {
+	parsed, err := strconv.ParseInt(strings.TrimSpace(string(text)), 10, 64)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf("dgkala: parse ID %q: %w", text, ErrInvalidID)
+	}
+	*id = parsed
+	return nil
+}
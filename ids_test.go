@@ -0,0 +1,105 @@
+package dgkala
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseProductID(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ProductID
+		wantErr error
+	}{
+		{"Test should parse a number", "6071", 6071, nil},
+		{"Test should parse a product code", "dkp-6071", 6071, nil},
+		{"Test should parse a product URL", "https://www.digikala.com/product/dkp-6071/case-logic-dlbp", 6071, nil},
+		{"Test should parse a product path", "/product/DKP-6071", 6071, nil},
+		{"Test should parse IDs larger than 32 bits", "9007199254740993", 9007199254740993, nil},
+		{"Test should fail for zero", "0", 0, ErrInvalidID},
+		{"Test should fail for negative numbers", "-5", 0, ErrInvalidID},
+		{"Test should fail for URLs without product code", "https://www.digikala.com/search/", 0, ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProductID(tt.input)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseProductID() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProductID_JSON(t *testing.T) {
+	type item struct {
+		ID    ProductID
+		Offer OfferID
+	}
+	tests := []struct {
+		name    string
+		input   string
+		want    item
+		wantErr bool
+	}{
+		{"Test should decode numbers", `{"ID":6071,"Offer":12}`, item{6071, 12}, false},
+		{"Test should decode strings", `{"ID":"6071","Offer":"12"}`, item{6071, 12}, false},
+		{"Test should ignore null", `{"ID":null}`, item{}, false},
+		{"Test should fail for invalid numbers", `{"ID":"phone"}`, item{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got item
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+				t.Errorf("json.Unmarshal() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+
+	encoded, err := json.Marshal(map[ProductID]item{6071: {6071, 12}})
+	if want := `{"6071":{"ID":6071,"Offer":12}}`; err != nil || string(encoded) != want {
+		t.Errorf("json.Marshal() = %s, %v, want %s", encoded, err, want)
+	}
+}
+
+func TestProductID_Code(t *testing.T) {
+	if got := ProductID(6071).Code(); got != "dkp-6071" {
+		t.Errorf("Code() = %v, want dkp-6071", got)
+	}
+}
+
+func TestID_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    int64
+		wantErr error
+	}{
+		{"Test should decode numbers", "6071", 6071, nil},
+		{"Test should decode zero as no ID", "0", 0, nil},
+		{"Test should fail for negative numbers", "-5", 0, ErrInvalidID},
+		{"Test should fail for invalid numbers", "phone", 0, ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var product ProductID
+			var offer OfferID
+			var comment CommentID
+			for i, unmarshal := range []func([]byte) error{product.UnmarshalText, offer.UnmarshalText, comment.UnmarshalText} {
+				if err := unmarshal([]byte(tt.text)); !errors.Is(err, tt.wantErr) {
+					t.Errorf("UnmarshalText() %d error = %v, want %v", i, err, tt.wantErr)
+				}
+			}
+			if int64(product) != tt.want || int64(offer) != tt.want || int64(comment) != tt.want {
+				t.Errorf("UnmarshalText() = %v, %v, %v, want %v", product, offer, comment, tt.want)
+			}
+		})
+	}
+
+	var product ProductID
+	if err := product.UnmarshalText([]byte("dkp-6071")); err != nil || product != 6071 {
+		t.Errorf("UnmarshalText(dkp-6071) = %v, %v, want 6071", product, err)
+	}
+}
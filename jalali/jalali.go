@@ -0,0 +1,243 @@
+// Package jalali converts dates between the Gregorian and the Jalali (Solar Hijri) calendars
+// and formats Jalali dates in English and Persian.
+//
+// The conversion uses the algorithm of jalaali-js which supports Jalali years -61 to 3177.
+package jalali
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Month is a month of the Jalali calendar
+type Month int
+
+const (
+	// Farvardin is the first month of the Jalali calendar
+	Farvardin Month = 1 + iota
+	Ordibehesht
+	Khordad
+	Tir
+	Mordad
+	Shahrivar
+	Mehr
+	Aban
+	Azar
+	Dey
+	Bahman
+	// Esfand is the last month of the Jalali calendar
+	Esfand
+)
+
+var monthNames = [...]string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+var persianMonthNames = [...]string{
+	"فروردین", "اردیبهشت", "خرداد", "تیر", "مرداد", "شهریور",
+	"مهر", "آبان", "آذر", "دی", "بهمن", "اسفند",
+}
+
+// String returns the English name of the month
+func (m Month) String() string {
+	if m < Farvardin || m > Esfand {
+		return fmt.Sprintf("Month(%d)", int(m))
+	}
+	return monthNames[m-1]
+}
+
+// PersianName returns the Persian name of the month
+func (m Month) PersianName() string {
+	if m < Farvardin || m > Esfand {
+		return m.String()
+	}
+	return persianMonthNames[m-1]
+}
+
+// Date is a Jalali date. The zero value means no date.
+type Date struct {
+	Year  int
+	Month Month
+	Day   int
+}
+
+// FromTime returns the Jalali date of a time in its location.
+// It returns the zero Date for times out of the supported years.
+func FromTime(t time.Time) Date {
+	year, month, day := t.Date()
+	return FromGregorian(year, month, day)
+}
+
+// FromGregorian converts a Gregorian date to a Jalali date.
+// It returns the zero Date for dates out of the supported years.
+func FromGregorian(year int, month time.Month, day int) Date {
+	// dates before Nowruz are in the previous Jalali year
+	jy := year - 621
+	if jy-1 < breaks[0] || jy >= breaks[len(breaks)-1] {
+		return Date{}
+	}
+	return fromDayNumber(gregorianToDayNumber(year, int(month), day))
+}
+
+// Gregorian returns the Gregorian date of the Jalali date
+func (d Date) Gregorian() (year int, month time.Month, day int) {
+	if !d.IsValid() {
+		return 0, 0, 0
+	}
+	gy, gm, gd := dayNumberToGregorian(d.dayNumber())
+	return gy, time.Month(gm), gd
+}
+
+// Time returns midnight of the Jalali date in loc
+func (d Date) Time(loc *time.Location) time.Time {
+	year, month, day := d.Gregorian()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// IsZero reports whether the date is the zero Date
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// IsValid reports whether the date exists in the Jalali calendar and is in the supported years
+func (d Date) IsValid() bool {
+	if d.Year < breaks[0] || d.Year >= breaks[len(breaks)-1] || d.Month < Farvardin || d.Month > Esfand {
+		return false
+	}
+	return d.Day >= 1 && d.Day <= DaysIn(d.Year, d.Month)
+}
+
+// IsLeap reports whether a Jalali year is a leap year
+func IsLeap(year int) bool {
+	leap, _, _, ok := calendar(year)
+	return ok && leap == 0
+}
+
+// DaysIn returns the number of days of a month of a Jalali year
+func DaysIn(year int, month Month) int {
+	switch {
+	case month < Farvardin || month > Esfand:
+		return 0
+	case month <= Shahrivar:
+		return 31
+	case month <= Bahman || IsLeap(year):
+		return 30
+	}
+	return 29
+}
+
+// String formats the date like 1396/02/11
+func (d Date) String() string {
+	return fmt.Sprintf("%04d/%02d/%02d", d.Year, int(d.Month), d.Day)
+}
+
+// LongString formats the date with the English month name, like 11 Ordibehesht 1396
+func (d Date) LongString() string {
+	return fmt.Sprintf("%d %s %d", d.Day, d.Month, d.Year)
+}
+
+// PersianString formats the date with the Persian month name and digits, like ۱۱ اردیبهشت ۱۳۹۶
+func (d Date) PersianString() string {
+	return PersianDigits(strconv.Itoa(d.Day) + " " + d.Month.PersianName() + " " + strconv.Itoa(d.Year))
+}
+
+// PersianDigits replaces the English digits of s with Persian digits
+func PersianDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r - '0' + '۰'
+		}
+		return r
+	}, s)
+}
+
+// breaks are the Jalali years in which the leap year cycle changes
+var breaks = [...]int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+// calendar returns the number of years since the last leap year (0 for leap years),
+// the Gregorian year in which the Jalali year starts and the day of March of its first day
+func calendar(jy int) (leap, gy, march int, ok bool) {
+	if jy < breaks[0] || jy >= breaks[len(breaks)-1] {
+		return 0, 0, 0, false
+	}
+	gy = jy + 621
+	leapJ := -14
+	jp := breaks[0]
+	jump := 0
+	for i := 1; i < len(breaks); i++ {
+		jm := breaks[i]
+		jump = jm - jp
+		if jy < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+	n := jy - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gy/4 - (gy/100+1)*3/4 - 150
+	march = 20 + leapJ - leapG
+
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	leap = ((n+1)%33 - 1) % 4
+	if leap == -1 {
+		leap = 4
+	}
+	return leap, gy, march, true
+}
+
+// dayNumber returns the Julian day number of a valid date
+func (d Date) dayNumber() int {
+	_, gy, march, _ := calendar(d.Year)
+	jm := int(d.Month)
+	return gregorianToDayNumber(gy, 3, march) + (jm-1)*31 - jm/7*(jm-7) + d.Day - 1
+}
+
+// fromDayNumber returns the Jalali date of a Julian day number
+func fromDayNumber(jdn int) Date {
+	gy, _, _ := dayNumberToGregorian(jdn)
+	jy := gy - 621
+	leap, _, march, _ := calendar(jy)
+	k := jdn - gregorianToDayNumber(gy, 3, march)
+	if k >= 0 {
+		if k <= 185 {
+			return Date{Year: jy, Month: Month(1 + k/31), Day: k%31 + 1}
+		}
+		k -= 186
+	} else {
+		jy--
+		k += 179
+		if leap == 1 {
+			k++
+		}
+	}
+	return Date{Year: jy, Month: Month(7 + k/30), Day: k%30 + 1}
+}
+
+// gregorianToDayNumber returns the Julian day number of a Gregorian date
+func gregorianToDayNumber(gy, gm, gd int) int {
+	d := (gy+(gm-8)/6+100100)*1461/4 + (153*((gm+9)%12)+2)/5 + gd - 34840408
+	return d - (gy+100100+(gm-8)/6)/100*3/4 + 752
+}
+
+// dayNumberToGregorian returns the Gregorian date of a Julian day number
+func dayNumberToGregorian(jdn int) (gy, gm, gd int) {
+	j := 4*jdn + 139361631
+	j += (4*jdn+183187720)/146097*3/4*4 - 3908
+	i := j%1461/4*5 + 308
+	gd = i%153/5 + 1
+	gm = i/153%12 + 1
+	gy = j/1461 - 100100 + (8-gm)/6
+	return gy, gm, gd
+}
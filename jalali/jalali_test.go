@@ -0,0 +1,89 @@
+package jalali
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromGregorian(t *testing.T) {
+	tests := []struct {
+		name  string
+		year  int
+		month time.Month
+		day   int
+		want  Date
+	}{
+		{"Test should convert a date", 2017, time.May, 1, Date{1396, Ordibehesht, 11}},
+		{"Test should convert Nowruz", 2024, time.March, 20, Date{1403, Farvardin, 1}},
+		{"Test should convert the day before Nowruz", 2024, time.March, 19, Date{1402, Esfand, 29}},
+		{"Test should convert the last day of a leap year", 2025, time.March, 20, Date{1403, Esfand, 30}},
+		{"Test should convert dates of the second half of the year", 2023, time.December, 22, Date{1402, Dey, 1}},
+		{"Test should return the zero date for unsupported years", 1, time.January, 1, Date{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromGregorian(tt.year, tt.month, tt.day); got != tt.want {
+				t.Errorf("FromGregorian() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDate_Gregorian(t *testing.T) {
+	start := time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 20000; day++ {
+		want := start.AddDate(0, 0, day)
+		date := FromTime(want)
+		if !date.IsValid() {
+			t.Fatalf("FromTime(%v) = %v, which is not valid", want, date)
+		}
+		if got := date.Time(time.UTC); !got.Equal(want) {
+			t.Fatalf("FromTime(%v).Time() = %v", want, got)
+		}
+	}
+}
+
+func TestIsLeap(t *testing.T) {
+	tests := []struct {
+		year int
+		want bool
+	}{
+		{1395, true},
+		{1396, false},
+		{1399, true},
+		{1402, false},
+		{1403, true},
+	}
+	for _, tt := range tests {
+		if got := IsLeap(tt.year); got != tt.want {
+			t.Errorf("IsLeap(%v) = %v, want %v", tt.year, got, tt.want)
+		}
+	}
+	if got := DaysIn(1402, Esfand); got != 29 {
+		t.Errorf("DaysIn(1402, Esfand) = %v, want 29", got)
+	}
+	if (Date{1402, Esfand, 30}).IsValid() {
+		t.Errorf("Date{1402, Esfand, 30}.IsValid() = true, want false")
+	}
+}
+
+func TestDate_format(t *testing.T) {
+	date := Date{1396, Ordibehesht, 11}
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Test should format numeric dates", date.String(), "1396/02/11"},
+		{"Test should format English month names", date.LongString(), "11 Ordibehesht 1396"},
+		{"Test should format Persian dates", date.PersianString(), "۱۱ اردیبهشت ۱۳۹۶"},
+		{"Test should replace digits only", PersianDigits("ساعت 10:45"), "ساعت ۱۰:۴۵"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
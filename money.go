@@ -0,0 +1,228 @@
+package dgkala
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrMoneyOverflow is returned by Money conversions and arithmetic which overflow int64
+var ErrMoneyOverflow = errors.New("dgkala: money overflow")
+
+// MoneyUnit is the unit of a Money amount
+type MoneyUnit int
+
+const (
+	// Rial is the official currency unit of Iran. DGKala APIs return prices in Rials.
+	Rial MoneyUnit = iota
+	// Toman is ten Rials and is the unit most prices are displayed in
+	Toman
+)
+
+func (u MoneyUnit) String() string {
+	switch u {
+	case Rial:
+		return "Rial"
+	case Toman:
+		return "Toman"
+	}
+	return fmt.Sprintf("MoneyUnit(%d)", int(u))
+}
+
+// persianName returns the Persian name of the unit
+func (u MoneyUnit) persianName() string {
+	switch u {
+	case Rial:
+		return "ریال"
+	case Toman:
+		return "تومان"
+	}
+	return u.String()
+}
+
+// Locale selects the language used to format values
+type Locale int
+
+const (
+	// LocaleEnglish formats values using English digits and names, like 12,345,000 Toman
+	LocaleEnglish Locale = iota
+	// LocalePersian formats values using Persian digits and names, like ۱۲٬۳۴۵٬۰۰۰ تومان
+	LocalePersian
+)
+
+// Money is an amount of money in a unit.
+// It's decoded from and encoded to JSON as a number of Rials.
+type Money struct {
+	Amount int64
+	Unit   MoneyUnit
+}
+
+// Rials returns an amount of money in Rials
+func Rials(amount int64) Money {
+	return Money{Amount: amount, Unit: Rial}
+}
+
+// Tomans returns an amount of money in Tomans
+func Tomans(amount int64) Money {
+	return Money{Amount: amount, Unit: Toman}
+}
+
+// ToRial converts the money to Rials
+func (m Money) ToRial() (Money, error) {
+	if m.Unit == Rial {
+		return m, nil
+	}
+	if m.Amount > math.MaxInt64/10 || m.Amount < math.MinInt64/10 {
+		return Money{}, fmt.Errorf("dgkala: convert %v to Rial: %w", m, ErrMoneyOverflow)
+	}
+	return Rials(m.Amount * 10), nil
+}
+
+// ToToman converts the money to Tomans. Rials are rounded to the nearest Toman, halves away from zero.
+func (m Money) ToToman() Money {
+	if m.Unit == Toman {
+		return m
+	}
+	amount := m.Amount / 10
+	if remainder := m.Amount % 10; remainder >= 5 {
+		amount++
+	} else if remainder <= -5 {
+		amount--
+	}
+	return Tomans(amount)
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Cmp compares two amounts of money and returns -1, 0 or +1 if m is less than, equal to or greater than other
+func (m Money) Cmp(other Money) int {
+	a, aRials := m.split()
+	b, bRials := other.split()
+	switch {
+	case a < b || (a == b && aRials < bRials):
+		return -1
+	case a > b || (a == b && aRials > bRials):
+		return 1
+	}
+	return 0
+}
+
+// split splits the money into whole Tomans and the remaining Rials, so amounts of different units can be compared without overflow
+func (m Money) split() (int64, int64) {
+	if m.Unit == Toman {
+		return m.Amount, 0
+	}
+	return m.Amount / 10, m.Amount % 10
+}
+
+// Add returns m + other. Amounts of different units are added in Rials.
+func (m Money) Add(other Money) (Money, error) {
+	a, b, err := sameUnit(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+	if (b.Amount > 0 && a.Amount > math.MaxInt64-b.Amount) || (b.Amount < 0 && a.Amount < math.MinInt64-b.Amount) {
+		return Money{}, fmt.Errorf("dgkala: add %v to %v: %w", other, m, ErrMoneyOverflow)
+	}
+	return Money{Amount: a.Amount + b.Amount, Unit: a.Unit}, nil
+}
+
+// Sub returns m - other. Amounts of different units are subtracted in Rials.
+func (m Money) Sub(other Money) (Money, error) {
+	a, b, err := sameUnit(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+	if (b.Amount < 0 && a.Amount > math.MaxInt64+b.Amount) || (b.Amount > 0 && a.Amount < math.MinInt64+b.Amount) {
+		return Money{}, fmt.Errorf("dgkala: subtract %v from %v: %w", other, m, ErrMoneyOverflow)
+	}
+	return Money{Amount: a.Amount - b.Amount, Unit: a.Unit}, nil
+}
+
+// Mul returns m * n
+func (m Money) Mul(n int64) (Money, error) {
+	if m.Amount == 0 || n == 0 {
+		return Money{Unit: m.Unit}, nil
+	}
+	product := m.Amount * n
+	if product/n != m.Amount || (n == -1 && m.Amount == math.MinInt64) {
+		return Money{}, fmt.Errorf("dgkala: multiply %v by %d: %w", m, n, ErrMoneyOverflow)
+	}
+	return Money{Amount: product, Unit: m.Unit}, nil
+}
+
+// sameUnit returns the amounts in the same unit, converting them to Rials if their units are different
+func sameUnit(a, b Money) (Money, Money, error) {
+	if a.Unit == b.Unit {
+		return a, b, nil
+	}
+	a, err := a.ToRial()
+	if err != nil {
+		return Money{}, Money{}, err
+	}
+	b, err = b.ToRial()
+	if err != nil {
+		return Money{}, Money{}, err
+	}
+	return a, b, nil
+}
+
+// Format formats the money in its own unit with grouped digits, like 12,345,000 Toman
+func (m Money) Format(locale Locale) string {
+	digits := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	separator, unit := ",", m.Unit.String()
+	if locale == LocalePersian {
+		separator, unit = "٬", m.Unit.persianName()
+	}
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(separator)
+		}
+		if locale == LocalePersian {
+			digit += '۰' - '0'
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + grouped.String() + " " + unit
+}
+
+func (m Money) String() string {
+	return m.Format(LocaleEnglish)
+}
+
+// MarshalJSON encodes the money as a JSON number of Rials
+func (m Money) MarshalJSON() ([]byte, error) {
+	rials, err := m.ToRial()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strconv.FormatInt(rials.Amount, 10)), nil
+}
+
+// UnmarshalJSON decodes the money from a JSON number or string of Rials. null leaves the money unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	amount, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("dgkala: decode money %s: %w", data, err)
+	}
+	*m = Rials(amount)
+	return nil
+}
@@ -0,0 +1,122 @@
+package dgkala
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoney_Format(t *testing.T) {
+	tests := []struct {
+		name   string
+		money  Money
+		locale Locale
+		want   string
+	}{
+		{"Test should format English Tomans", Tomans(12345000), LocaleEnglish, "12,345,000 Toman"},
+		{"Test should format Persian Tomans", Tomans(12345000), LocalePersian, "۱۲٬۳۴۵٬۰۰۰ تومان"},
+		{"Test should format Persian Rials", Rials(950), LocalePersian, "۹۵۰ ریال"},
+		{"Test should format negative amounts", Rials(-1234), LocaleEnglish, "-1,234 Rial"},
+		{"Test should format zero", Money{}, LocaleEnglish, "0 Rial"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.Format(tt.locale); got != tt.want {
+				t.Errorf("Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_conversions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{"Test should convert Rials to Tomans", Rials(123450).ToToman(), Tomans(12345)},
+		{"Test should round Rials half up", Rials(125).ToToman(), Tomans(13)},
+		{"Test should round Rials down", Rials(124).ToToman(), Tomans(12)},
+		{"Test should round negative Rials away from zero", Rials(-125).ToToman(), Tomans(-13)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("ToToman() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if got, err := Tomans(12345).ToRial(); got != Rials(123450) || err != nil {
+		t.Errorf("ToRial() = %v, %v, want %v", got, err, Rials(123450))
+	}
+	if _, err := Tomans(math.MaxInt64 / 5).ToRial(); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("ToRial() error = %v, want %v", err, ErrMoneyOverflow)
+	}
+}
+
+func TestMoney_arithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		do      func() (Money, error)
+		want    Money
+		wantErr error
+	}{
+		{"Test should add same units", func() (Money, error) { return Tomans(10).Add(Tomans(5)) }, Tomans(15), nil},
+		{"Test should add different units in Rials", func() (Money, error) { return Tomans(10).Add(Rials(5)) }, Rials(105), nil},
+		{"Test should subtract", func() (Money, error) { return Rials(10).Sub(Rials(15)) }, Rials(-5), nil},
+		{"Test should multiply", func() (Money, error) { return Tomans(12).Mul(3) }, Tomans(36), nil},
+		{"Test should detect add overflow", func() (Money, error) { return Rials(math.MaxInt64).Add(Rials(1)) }, Money{}, ErrMoneyOverflow},
+		{"Test should detect subtract overflow", func() (Money, error) { return Rials(math.MinInt64).Sub(Rials(1)) }, Money{}, ErrMoneyOverflow},
+		{"Test should detect multiply overflow", func() (Money, error) { return Rials(math.MaxInt64 / 2).Mul(3) }, Money{}, ErrMoneyOverflow},
+		{"Test should detect conversion overflow", func() (Money, error) { return Tomans(math.MaxInt64).Add(Rials(1)) }, Money{}, ErrMoneyOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.do()
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoney_Cmp(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Money
+		want int
+	}{
+		{"Test should compare same units", Rials(5), Rials(10), -1},
+		{"Test should compare different units", Tomans(10), Rials(100), 0},
+		{"Test should compare Rial remainders", Rials(101), Tomans(10), 1},
+		{"Test should compare negative remainders", Rials(-5), Tomans(-1), 1},
+		{"Test should compare amounts overflowing in Rials", Tomans(math.MaxInt64), Rials(math.MaxInt64), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Cmp(tt.b); got != tt.want {
+				t.Errorf("Cmp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var got struct{ Price, Discount, Missing Money }
+	if err := json.Unmarshal([]byte(`{"Price":1450000,"Discount":"50000","Missing":null}`), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got.Price != Rials(1450000) || got.Discount != Rials(50000) || got.Missing != (Money{}) {
+		t.Errorf("json.Unmarshal() = %+v", got)
+	}
+	if err := json.Unmarshal([]byte(`{"Price":1e30}`), &got); err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want an error")
+	}
+
+	encoded, err := json.Marshal(Tomans(145000))
+	if string(encoded) != "1450000" || err != nil {
+		t.Errorf("json.Marshal() = %s, %v, want 1450000", encoded, err)
+	}
+}
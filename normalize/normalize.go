@@ -0,0 +1,105 @@
+// Package normalize normalizes Persian text, so texts written with Arabic letters,
+// different digits, zero width characters or diacritics can be searched and compared.
+package normalize
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	zwnj = '\u200c'
+	// tatweel is the Arabic letter elongation character
+	tatweel = '\u0640'
+)
+
+// characters maps Arabic letters to their Persian forms
+var characters = map[rune]rune{
+	'ي': 'ی', // Arabic yeh
+	'ى': 'ی', // Arabic alef maksura
+	'ك': 'ک', // Arabic kaf
+	'ە': 'ه', // Arabic letter ae
+	'ة': 'ه', // Arabic teh marbuta
+}
+
+// String applies every normalization of the package and collapses whitespace:
+// it unifies characters, folds digits, normalizes ZWNJs and strips diacritics
+func String(s string) string {
+	s = ZWNJ(StripDiacritics(Digits(Characters(s))))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Key normalizes s for comparing texts: it's String without ZWNJs and in lower case,
+// so "کتاب‌ها" and "كتابها" have the same key
+func Key(s string) string {
+	return strings.ToLower(strings.ReplaceAll(String(s), string(zwnj), ""))
+}
+
+// Characters replaces Arabic yeh, kaf and heh variants with their Persian forms
+func Characters(s string) string {
+	return strings.Map(func(r rune) rune {
+		if persian, ok := characters[r]; ok {
+			return persian
+		}
+		return r
+	}, s)
+}
+
+// Digits replaces Persian and Arabic digits with ASCII digits
+func Digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '۰' && r <= '۹':
+			return r - '۰' + '0'
+		case r >= '٠' && r <= '٩':
+			return r - '٠' + '0'
+		}
+		return r
+	}, s)
+}
+
+// StripDiacritics removes Arabic diacritics like fatha and tanwin, and tatweels
+func StripDiacritics(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '\u064b' && r <= '\u065f') || r == '\u0670' || r == tatweel {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// ZWNJ normalizes zero width characters: other zero width characters are removed,
+// repeated ZWNJs are collapsed and ZWNJs next to spaces or at the ends of s are removed
+func ZWNJ(s string) string {
+	normalized := make([]rune, 0, len(s))
+	for _, r := range s {
+		last := rune(-1)
+		if len(normalized) > 0 {
+			last = normalized[len(normalized)-1]
+		}
+		switch {
+		case isZeroWidth(r):
+			continue
+		case r == zwnj:
+			if last == -1 || last == zwnj || unicode.IsSpace(last) {
+				continue
+			}
+		case unicode.IsSpace(r) && last == zwnj:
+			normalized = normalized[:len(normalized)-1]
+		}
+		normalized = append(normalized, r)
+	}
+	if len(normalized) > 0 && normalized[len(normalized)-1] == zwnj {
+		normalized = normalized[:len(normalized)-1]
+	}
+	return string(normalized)
+}
+
+// isZeroWidth reports whether r is a zero width character other than ZWNJ
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u200b', '\u200d', '\u2060', '\ufeff', '\u00ad':
+		return true
+	}
+	return false
+}
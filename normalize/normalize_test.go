@@ -0,0 +1,42 @@
+package normalize
+
+import "testing"
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Test should unify Arabic letters", "كيف مشكي", "کیف مشکی"},
+		{"Test should fold Persian and Arabic digits", "آیفون ۱۳ و ٤ گیگ", "آیفون 13 و 4 گیگ"},
+		{"Test should strip diacritics and tatweels", "کتــابُ", "کتاب"},
+		{"Test should collapse repeated ZWNJs", "کتاب\u200c\u200cها", "کتاب\u200cها"},
+		{"Test should remove ZWNJs next to spaces", "\u200cمی\u200c خواهم\u200c", "می خواهم"},
+		{"Test should remove other zero width characters", "گوشی\u200b\u200d\ufeff سامسونگ", "گوشی سامسونگ"},
+		{"Test should collapse whitespace", "  گوشی \t  موبایل  ", "گوشی موبایل"},
+		{"Test should keep Latin text", "Galaxy S21", "Galaxy S21"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := String(tt.input); got != tt.want {
+				t.Errorf("String(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"کتاب\u200cها", "كتابها"},
+		{"Galaxy S۲۱", "galaxy s21"},
+		{"مُشکی", "مشكي"},
+	}
+	for _, tt := range tests {
+		if Key(tt.a) != Key(tt.b) {
+			t.Errorf("Key(%q) = %q, Key(%q) = %q, want equal keys", tt.a, Key(tt.a), tt.b, Key(tt.b))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package dgkala
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// ErrInvalidOffer is matched by errors returned by IncredibleOffer.Validate
+var ErrInvalidOffer = errors.New("dgkala: invalid offer")
+
+// OfferSort is the sort order of incredible offers
+type OfferSort int
+
+const (
+	// OfferSortSaving sorts offers by their saving amount, biggest first
+	OfferSortSaving OfferSort = iota
+	// OfferSortDiscountPercent sorts offers by their discount percentage, biggest first
+	OfferSortDiscountPercent
+)
+
+// OriginalPrice returns the price of the offer product before the discount, which is Price plus the saving.
+// It's zero if the sum overflows.
+func (o IncredibleOffer) OriginalPrice() Money {
+	price, err := o.Price.Add(o.Saving())
+	if err != nil {
+		return Money{Unit: o.Price.Unit}
+	}
+	return price
+}
+
+// DiscountedPrice returns the price of the offer product after the discount, which is its Price
+func (o IncredibleOffer) DiscountedPrice() Money {
+	return o.Price
+}
+
+// Saving returns the amount of the discount. It's zero for negative discounts.
+func (o IncredibleOffer) Saving() Money {
+	if o.Discount.Amount <= 0 {
+		return Money{Unit: o.Discount.Unit}
+	}
+	return o.Discount
+}
+
+// DiscountPercent returns the discount as a percentage of the original price,
+// rounded to the nearest whole percent with halves rounded up.
+// It's zero for offers without a price.
+func (o IncredibleOffer) DiscountPercent() int {
+	price, err := o.Price.ToRial()
+	if err != nil || price.Amount <= 0 {
+		return 0
+	}
+	saving, err := o.Saving().ToRial()
+	if err != nil {
+		return 0
+	}
+	// round(100 * saving / original) = (200 * saving + original) / (2 * original)
+	original := new(big.Int).Add(big.NewInt(price.Amount), big.NewInt(saving.Amount))
+	numerator := new(big.Int).Mul(big.NewInt(saving.Amount), big.NewInt(200))
+	numerator.Add(numerator, original)
+	denominator := new(big.Int).Mul(original, big.NewInt(2))
+	return int(numerator.Quo(numerator, denominator).Int64())
+}
+
+// Validate returns an error matching ErrInvalidOffer if the price or discount of the offer are inconsistent
+func (o IncredibleOffer) Validate() error {
+	switch {
+	case o.Price.Amount <= 0:
+		return fmt.Errorf("dgkala: offer %v: price %v is not positive: %w", o.ID, o.Price, ErrInvalidOffer)
+	case o.Discount.Amount < 0:
+		return fmt.Errorf("dgkala: offer %v: discount %v is negative: %w", o.ID, o.Discount, ErrInvalidOffer)
+	}
+	if _, err := o.Price.Add(o.Discount); err != nil {
+		return fmt.Errorf("dgkala: offer %v: original price: %v: %w", o.ID, err, ErrInvalidOffer)
+	}
+	return nil
+}
+
+// SortOffers sorts incredible offers in place. Invalid offers are moved to the end.
+// Offers with equal savings keep their order.
+func SortOffers(offers []IncredibleOffer, order OfferSort) {
+	sorter := offerSorter{offers: offers, keys: make([]offerSortKey, len(offers)), order: order}
+	for i, offer := range offers {
+		key := offerSortKey{valid: offer.Validate() == nil}
+		if order == OfferSortDiscountPercent {
+			key.percent = offer.DiscountPercent()
+		} else {
+			key.saving = offer.Saving()
+		}
+		sorter.keys[i] = key
+	}
+	sort.Stable(sorter)
+}
+
+// offerSortKey is what offers are compared by, computed once for each offer
+type offerSortKey struct {
+	valid   bool
+	saving  Money
+	percent int
+}
+
+// offerSorter sorts offers along with their sort keys
+type offerSorter struct {
+	offers []IncredibleOffer
+	keys   []offerSortKey
+	order  OfferSort
+}
+
+func (s offerSorter) Len() int {
+	return len(s.offers)
+}
+
+func (s offerSorter) Less(i, j int) bool {
+	a, b := s.keys[i], s.keys[j]
+	if a.valid != b.valid {
+		return a.valid
+	}
+	if s.order == OfferSortDiscountPercent {
+		return a.percent > b.percent
+	}
+	return a.saving.Cmp(b.saving) > 0
+}
+
+func (s offerSorter) Swap(i, j int) {
+	s.offers[i], s.offers[j] = s.offers[j], s.offers[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
@@ -0,0 +1,131 @@
+package dgkala
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestIncredibleOffer_pricing(t *testing.T) {
+	tests := []struct {
+		name            string
+		offer           IncredibleOffer
+		wantOriginal    Money
+		wantSaving      Money
+		wantPercent     int
+		wantValidateErr error
+	}{
+		{
+			name:         "Test should compute the original price",
+			offer:        IncredibleOffer{Price: Rials(1000000), Discount: Rials(250000)},
+			wantOriginal: Rials(1250000),
+			wantSaving:   Rials(250000),
+			wantPercent:  20,
+		},
+		{
+			name:         "Test should round percentages halves up",
+			offer:        IncredibleOffer{Price: Rials(171), Discount: Rials(29)},
+			wantOriginal: Rials(200),
+			wantSaving:   Rials(29),
+			wantPercent:  15,
+		},
+		{
+			name:         "Test should round percentages down",
+			offer:        IncredibleOffer{Price: Rials(200), Discount: Rials(100)},
+			wantOriginal: Rials(300),
+			wantSaving:   Rials(100),
+			wantPercent:  33,
+		},
+		{
+			name:         "Test should compute offers without discount",
+			offer:        IncredibleOffer{Price: Rials(1000)},
+			wantOriginal: Rials(1000),
+			wantSaving:   Rials(0),
+			wantPercent:  0,
+		},
+		{
+			name:         "Test should allow discounts greater than the price",
+			offer:        IncredibleOffer{Price: Rials(1000), Discount: Rials(1500)},
+			wantOriginal: Rials(2500),
+			wantSaving:   Rials(1500),
+			wantPercent:  60,
+		},
+		{
+			name:            "Test should flag offers without price",
+			offer:           IncredibleOffer{Discount: Rials(1500)},
+			wantOriginal:    Rials(1500),
+			wantSaving:      Rials(1500),
+			wantPercent:     0,
+			wantValidateErr: ErrInvalidOffer,
+		},
+		{
+			name:            "Test should flag negative discounts",
+			offer:           IncredibleOffer{Price: Rials(1000), Discount: Rials(-10)},
+			wantOriginal:    Rials(1000),
+			wantSaving:      Rials(0),
+			wantPercent:     0,
+			wantValidateErr: ErrInvalidOffer,
+		},
+		{
+			name:            "Test should flag original prices which overflow",
+			offer:           IncredibleOffer{Price: Rials(math.MaxInt64), Discount: Rials(10)},
+			wantOriginal:    Rials(0),
+			wantSaving:      Rials(10),
+			wantPercent:     0,
+			wantValidateErr: ErrInvalidOffer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.offer.OriginalPrice(); got != tt.wantOriginal {
+				t.Errorf("OriginalPrice() = %v, want %v", got, tt.wantOriginal)
+			}
+			if got := tt.offer.DiscountedPrice(); got != tt.offer.Price {
+				t.Errorf("DiscountedPrice() = %v, want %v", got, tt.offer.Price)
+			}
+			if got := tt.offer.Saving(); got != tt.wantSaving {
+				t.Errorf("Saving() = %v, want %v", got, tt.wantSaving)
+			}
+			if got := tt.offer.DiscountPercent(); got != tt.wantPercent {
+				t.Errorf("DiscountPercent() = %v, want %v", got, tt.wantPercent)
+			}
+			if err := tt.offer.Validate(); !errors.Is(err, tt.wantValidateErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantValidateErr)
+			}
+		})
+	}
+}
+
+func TestSortOffers(t *testing.T) {
+	offers := func() []IncredibleOffer {
+		return []IncredibleOffer{
+			{ID: 1, Price: Rials(1000), Discount: Rials(100)},
+			{ID: 2, Price: Rials(0), Discount: Rials(500)},
+			{ID: 3, Price: Rials(10000), Discount: Rials(500)},
+			{ID: 4, Price: Rials(400), Discount: Rials(200)},
+			{ID: 5, Price: Rials(2000), Discount: Rials(200)},
+		}
+	}
+	tests := []struct {
+		name  string
+		order OfferSort
+		want  []OfferID
+	}{
+		{"Test should sort by saving", OfferSortSaving, []OfferID{3, 4, 5, 1, 2}},
+		{"Test should sort by discount percent", OfferSortDiscountPercent, []OfferID{4, 1, 5, 3, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := offers()
+			SortOffers(sorted, tt.order)
+			var got []OfferID
+			for _, offer := range sorted {
+				got = append(got, offer.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortOffers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
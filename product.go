@@ -0,0 +1,64 @@
+package dgkala
+
+// Specification is a technical specification of a product
+type Specification struct {
+	Key   string `json:"Title"`
+	Value string
+	// Unit is the unit of the value, like "mAh", empty for values without a unit
+	Unit string
+}
+
+// SpecificationGroup is a titled group of product specifications, like "Display" or "Battery"
+type SpecificationGroup struct {
+	Title          string
+	Specifications []Specification `json:"Attributes"`
+}
+
+// ProductCategory is a category in the category path of a product
+type ProductCategory struct {
+	ID      CategoryID `json:"Id"`
+	Title   string
+	URLCode string `json:"UrlCode"`
+}
+
+// Brand is a product brand
+type Brand struct {
+	ID           BrandID `json:"Id"`
+	EnglishTitle string  `json:"EnTitle"`
+	PersianTitle string  `json:"FaTitle"`
+	URLCode      string  `json:"UrlCode"`
+}
+
+// ProductVariant is a variant of a product with a specific color, size and warranty
+type ProductVariant struct {
+	ID           VariantID `json:"Id"`
+	Color        ProductColor
+	Size         string
+	Warranty     string
+	Price        Money
+	ExistsStatus ProductExistsStatus `json:"ExistStatus"`
+}
+
+// SellerOffer is the offer of a seller for a product variant
+type SellerOffer struct {
+	SellerID   SellerID `json:"SellerId"`
+	SellerName string
+	VariantID  VariantID `json:"VariantId"`
+	Price      Money
+	Stock      uint
+}
+
+// ProductRating contains the user ratings of a product
+type ProductRating struct {
+	Average float64
+	Count   uint
+	// Breakdown is the number of ratings of each score, from 1 to 5
+	Breakdown map[int]uint
+}
+
+// ProductDimensions contains the package dimensions of a product in centimeters
+type ProductDimensions struct {
+	Length float64
+	Width  float64
+	Height float64
+}
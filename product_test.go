@@ -0,0 +1,110 @@
+package dgkala
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClient_GetProductByIDDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/product_6071.json")
+	}))
+	defer server.Close()
+
+	got, err := NewClient(WithService2BaseURL(server.URL)).GetProductByID(context.Background(), 6071)
+	if err != nil {
+		t.Fatalf("GetProductByID() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{
+			name: "Test should decode specifications",
+			got:  got.Specifications,
+			want: []SpecificationGroup{
+				{
+					Title: "مشخصات فیزیکی",
+					Specifications: []Specification{
+						{Key: "ابعاد", Value: "46 × 32 × 18", Unit: "سانتی‌متر"},
+						{Key: "وزن", Value: "900", Unit: "گرم"},
+					},
+				},
+				{
+					Title:          "مشخصات کلی",
+					Specifications: []Specification{{Key: "جنس", Value: "پلی‌استر"}},
+				},
+			},
+		},
+		{
+			name: "Test should decode the category path",
+			got:  got.Categories,
+			want: []ProductCategory{
+				{ID: 5966, Title: "لوازم جانبی کامپیوتر", URLCode: "computer-accessories"},
+				{ID: 6158, Title: "کیف و کوله لپ تاپ", URLCode: "laptop-bag"},
+			},
+		},
+		{
+			name: "Test should decode the brand",
+			got:  got.Brand,
+			want: Brand{ID: 1152, EnglishTitle: "Case Logic", PersianTitle: "کیس لاجیک", URLCode: "case-logic"},
+		},
+		{
+			name: "Test should decode variants",
+			got:  got.Variants,
+			want: []ProductVariant{
+				{
+					ID:           101,
+					Color:        ProductColor{Title: "مشکی", Hex: "#000000", Code: "black"},
+					Size:         "16 inch",
+					Warranty:     "گارانتی 18 ماهه",
+					Price:        Rials(1450000),
+					ExistsStatus: Available,
+				},
+				{
+					ID:           102,
+					Color:        ProductColor{Title: "آبی", Hex: "#0000ff", Code: "blue"},
+					Size:         "16 inch",
+					Warranty:     "گارانتی 18 ماهه",
+					Price:        Rials(1520000),
+					ExistsStatus: OutOfStock,
+				},
+			},
+		},
+		{
+			name: "Test should decode seller offers",
+			got:  got.Sellers,
+			want: []SellerOffer{
+				{SellerID: 1, SellerName: "دیجی‌کالا", VariantID: 101, Price: Rials(1450000), Stock: 12},
+				{SellerID: 4512, SellerName: "فروشگاه کیف", VariantID: 101, Price: Rials(1480000), Stock: 3},
+			},
+		},
+		{
+			name: "Test should decode the rating breakdown",
+			got:  got.Rating,
+			want: ProductRating{Average: 4.2, Count: 37, Breakdown: map[int]uint{1: 1, 2: 2, 3: 4, 4: 10, 5: 20}},
+		},
+		{
+			name: "Test should decode weight and dimensions",
+			got:  []interface{}{got.Weight, got.Dimensions},
+			want: []interface{}{900.0, ProductDimensions{Length: 46, Width: 32, Height: 18}},
+		},
+		{
+			name: "Test should keep the basic fields",
+			got:  []interface{}{got.ID, got.MinPrice, got.Strengths, got.ImagePaths.Size70},
+			want: []interface{}{ProductID(6071), Rials(1450000), "جادار\r\nمقاوم در برابر آب", "Image/Webstore/Product/P_6071/70/Case_Logic_DLBP.jpg"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("GetProductByID() = %+v, want %+v", tt.got, tt.want)
+			}
+		})
+	}
+}
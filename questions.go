@@ -0,0 +1,82 @@
+package dgkala
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// QuestionOptions configures the page of product questions to get
+type QuestionOptions struct {
+	// Page is the page number, starting from 1. Zero means the first page.
+	Page int
+	// PageSize is the number of questions in each page. Zero uses the API default.
+	PageSize int
+}
+
+// Answer is an answer to a product question
+type Answer struct {
+	ID        AnswerID `json:"Id"`
+	Author    string   `json:"AuthorName"`
+	Text      string
+	CreatedAt Timestamp
+	// IsSeller is true if the answer is written by a seller of the product, not a user
+	IsSeller     bool
+	HelpfulVotes uint `json:"LikeCount"`
+}
+
+// Question is a user question about a product
+type Question struct {
+	ID        QuestionID `json:"Id"`
+	Author    string     `json:"AuthorName"`
+	Text      string
+	CreatedAt Timestamp
+	// AnswerCount is the number of answers of the question, which may be more than len(Answers)
+	AnswerCount int
+	Answers     []Answer
+}
+
+// QuestionsPage is a page of product questions
+type QuestionsPage struct {
+	Questions []Question
+	Page      int `json:"PageNo"`
+	PageCount int
+	// Total is the number of questions of the product
+	Total int
+}
+
+func (c *Client) getProductQuestionsAPIAddress(productID ProductID, options QuestionOptions) string {
+	query := url.Values{}
+	if options.Page > 0 {
+		query.Set("pageno", strconv.Itoa(options.Page))
+	}
+	if options.PageSize > 0 {
+		query.Set("pagesize", strconv.Itoa(options.PageSize))
+	}
+	return c.service2BaseURL + fmt.Sprintf(productQuestionsAPIPath, productID, query.Encode())
+}
+
+// GetProductQuestions returns a page of the questions of a product with their answers
+func (c *Client) GetProductQuestions(ctx context.Context, productID ProductID, options QuestionOptions) (QuestionsPage, error) {
+	apiAddress := c.getProductQuestionsAPIAddress(productID, options)
+	return coalesce(c, ctx, EndpointProductQuestions, apiAddress, func(ctx context.Context) (QuestionsPage, error) {
+		return c.getProductQuestions(ctx, apiAddress)
+	})
+}
+
+func (c *Client) getProductQuestions(ctx context.Context, apiAddress string) (QuestionsPage, error) {
+	headers := getRequestHeaders()
+
+	var page QuestionsPage
+	err := c.get(ctx, EndpointProductQuestions, apiAddress, headers, func(body []byte) error {
+		return decodeService2Response(body, apiAddress, &page)
+	})
+	if err != nil {
+		return QuestionsPage{}, err
+	}
+	if page.Questions == nil {
+		page.Questions = []Question{}
+	}
+	return page, nil
+}
@@ -0,0 +1,58 @@
+package dgkala_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mamal72/dgkala"
+	"github.com/mamal72/dgkala/dgkalatest"
+)
+
+func TestClient_GetProductQuestions(t *testing.T) {
+	questions := []dgkala.Question{
+		{
+			ID:          1,
+			Author:      "مریم",
+			Text:        "ضد آب است؟",
+			CreatedAt:   dgkala.Timestamp{Time: time.Date(2017, 5, 1, 10, 0, 0, 0, dgkala.TehranLocation())},
+			AnswerCount: 2,
+			Answers: []dgkala.Answer{
+				{ID: 10, Author: "فروشگاه کیف", Text: "بله", CreatedAt: dgkala.Timestamp{Time: time.Date(2017, 5, 1, 11, 0, 0, 0, dgkala.TehranLocation())}, IsSeller: true, HelpfulVotes: 4},
+				{ID: 11, Author: "رضا", Text: "کاملا", CreatedAt: dgkala.Timestamp{Time: time.Date(2017, 5, 2, 9, 30, 0, 0, dgkala.TehranLocation())}},
+			},
+		},
+		{ID: 2, Text: "ابعاد؟", CreatedAt: dgkala.Timestamp{Time: time.Date(2017, 5, 3, 10, 0, 0, 0, dgkala.TehranLocation())}},
+		{ID: 3, Text: "رنگ؟", CreatedAt: dgkala.Timestamp{Time: time.Date(2017, 5, 4, 10, 0, 0, 0, dgkala.TehranLocation())}},
+	}
+	server := dgkalatest.NewServer(dgkalatest.WithQuestions(6071, questions...))
+	defer server.Close()
+	client := server.Client()
+
+	tests := []struct {
+		name          string
+		options       dgkala.QuestionOptions
+		want          []dgkala.Question
+		wantPage      int
+		wantPageCount int
+	}{
+		{"Test should return questions with answers", dgkala.QuestionOptions{}, questions, 1, 1},
+		{"Test should paginate", dgkala.QuestionOptions{Page: 2, PageSize: 2}, questions[2:], 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetProductQuestions(context.Background(), 6071, tt.options)
+			if err != nil {
+				t.Fatalf("GetProductQuestions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Questions, tt.want) {
+				t.Errorf("GetProductQuestions() = %+v, want %+v", got.Questions, tt.want)
+			}
+			if got.Total != 3 || got.Page != tt.wantPage || got.PageCount != tt.wantPageCount {
+				t.Errorf("GetProductQuestions() total = %v, page = %v/%v, want 3, %v/%v",
+					got.Total, got.Page, got.PageCount, tt.wantPage, tt.wantPageCount)
+			}
+		})
+	}
+}
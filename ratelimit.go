@@ -0,0 +1,201 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Host identifies one of the DGKala API hosts
+type Host int
+
+const (
+	// HostService2 is the host serving incredible offers and product details
+	HostService2 Host = iota
+	// HostSearch is the search API host
+	HostSearch
+	// HostFile is the static files host
+	HostFile
+)
+
+func (h Host) String() string {
+	switch h {
+	case HostService2:
+		return "service2"
+	case HostSearch:
+		return "search"
+	case HostFile:
+		return "file"
+	}
+	return fmt.Sprintf("Host(%d)", int(h))
+}
+
+// ErrRateLimited is matched by errors returned when a request is rejected by the client rate limiter
+var ErrRateLimited = errors.New("dgkala: rate limited")
+
+// RateLimitError is returned by fail fast rate limiters.
+// It matches ErrRateLimited.
+type RateLimitError struct {
+	Host Host
+	// Wait is how long the request would have had to wait for the limiter
+	Wait time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("dgkala: rate limited on %s host, retry in %s", e.Host, e.Wait)
+}
+
+// Is reports whether the error matches target
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RateLimitMode defines what happens when a request exceeds the rate limit
+type RateLimitMode int
+
+const (
+	// RateLimitWait blocks the request until it's allowed
+	RateLimitWait RateLimitMode = iota
+	// RateLimitFailFast returns a *RateLimitError instead of waiting
+	RateLimitFailFast
+)
+
+// RateLimit configures a token bucket rate limiter
+type RateLimit struct {
+	// Rate is the number of requests allowed per second
+	Rate float64
+	// Burst is the maximum number of requests allowed at once. Values lower than 1 mean 1.
+	Burst int
+	Mode  RateLimitMode
+	// OnWait is called with the wait time of every request delayed by the limiter
+	OnWait func(host Host, wait time.Duration)
+}
+
+// RateLimitStats contains metrics of a host rate limiter
+type RateLimitStats struct {
+	// Requests is the number of requests which passed through the limiter.
+	// Requests canceled while waiting are not counted.
+	Requests int64
+	// Delayed is the number of requests which had to wait
+	Delayed int64
+	// Rejected is the number of requests rejected in fail fast mode
+	Rejected  int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// WithRateLimit limits the requests sent to a host.
+// All the requests of a client share the limiter of their host.
+func WithRateLimit(host Host, limit RateLimit) Option {
+	return func(c *Client) {
+		c.rateLimits[host] = limit
+	}
+}
+
+// RateLimitStats returns the metrics of the rate limiter of a host
+func (c *Client) RateLimitStats(host Host) RateLimitStats {
+	bucket, ok := c.limiters[host]
+	if !ok {
+		return RateLimitStats{}
+	}
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+	return bucket.stats
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait for it.
+// In fail fast mode no token is taken if the caller would have to wait.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.last.IsZero() {
+		b.last = now
+	}
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+		if burst := float64(b.limit.Burst); b.tokens > burst {
+			b.tokens = burst
+		}
+		b.last = now
+	}
+
+	var wait time.Duration
+	if b.tokens < 1 {
+		wait = time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+	}
+	if wait > 0 && b.limit.Mode == RateLimitFailFast {
+		b.stats.Rejected++
+		return wait
+	}
+
+	b.tokens--
+	return wait
+}
+
+// done records a request which has waited for its reserved token
+func (b *tokenBucket) done(wait time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Requests++
+	if wait > 0 {
+		b.stats.Delayed++
+		b.stats.TotalWait += wait
+		if wait > b.stats.MaxWait {
+			b.stats.MaxWait = wait
+		}
+	}
+}
+
+// cancel gives back a reserved token of a request which didn't wait for it
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
+
+// waitRateLimit waits for the rate limiter of a host, if the client has one
+func (c *Client) waitRateLimit(ctx context.Context, host Host) error {
+	bucket, ok := c.limiters[host]
+	if !ok {
+		return nil
+	}
+
+	wait := bucket.reserve(c.now())
+	if wait <= 0 {
+		bucket.done(0)
+		return nil
+	}
+	if bucket.limit.Mode == RateLimitFailFast {
+		return &RateLimitError{Host: host, Wait: wait}
+	}
+	if bucket.limit.OnWait != nil {
+		bucket.limit.OnWait(host, wait)
+	}
+	if err := c.sleep(ctx, wait); err != nil {
+		bucket.cancel()
+		return err
+	}
+	bucket.done(wait)
+	return nil
+}
@@ -0,0 +1,152 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_rateLimitWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var reported []time.Duration
+	client := NewClient(WithRateLimit(HostSearch, RateLimit{
+		Rate:  10,
+		Burst: 2,
+		OnWait: func(host Host, wait time.Duration) {
+			reported = append(reported, wait)
+		},
+	}))
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+	var delays []time.Duration
+	client.sleep = func(_ context.Context, duration time.Duration) error {
+		delays = append(delays, duration)
+		return nil
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.fetch(context.Background(), HostSearch, server.URL, requestHeader{}); err != nil {
+			t.Fatalf("fetch() error = %v", err)
+		}
+	}
+	if _, err := client.fetch(context.Background(), HostService2, server.URL, requestHeader{}); err != nil {
+		t.Fatalf("fetch() error = %v", err)
+	}
+
+	wantDelays := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+	if !reflect.DeepEqual(delays, wantDelays) {
+		t.Errorf("fetch() delays = %v, want %v", delays, wantDelays)
+	}
+	if !reflect.DeepEqual(reported, wantDelays) {
+		t.Errorf("OnWait() waits = %v, want %v", reported, wantDelays)
+	}
+	wantStats := RateLimitStats{
+		Requests:  4,
+		Delayed:   2,
+		TotalWait: 300 * time.Millisecond,
+		MaxWait:   200 * time.Millisecond,
+	}
+	if got := client.RateLimitStats(HostSearch); got != wantStats {
+		t.Errorf("RateLimitStats() = %+v, want %+v", got, wantStats)
+	}
+	if got := client.RateLimitStats(HostService2); got != (RateLimitStats{}) {
+		t.Errorf("RateLimitStats() = %+v, want zero stats", got)
+	}
+}
+
+func TestClient_rateLimitFailFast(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(HostService2, RateLimit{Rate: 10, Mode: RateLimitFailFast}))
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		wantWait time.Duration
+	}{
+		{"Test should allow the first request", 0, 0},
+		{"Test should reject requests over the limit", 40 * time.Millisecond, 60 * time.Millisecond},
+		{"Test should allow requests after the bucket is refilled", 60 * time.Millisecond, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			_, err := client.fetch(context.Background(), HostService2, server.URL, requestHeader{})
+			if tt.wantWait == 0 {
+				if err != nil {
+					t.Errorf("fetch() error = %v", err)
+				}
+				return
+			}
+			var rateLimitErr *RateLimitError
+			if !errors.Is(err, ErrRateLimited) || !errors.As(err, &rateLimitErr) {
+				t.Fatalf("fetch() error = %v, want %v", err, ErrRateLimited)
+			}
+			if rateLimitErr.Wait != tt.wantWait {
+				t.Errorf("RateLimitError.Wait = %v, want %v", rateLimitErr.Wait, tt.wantWait)
+			}
+		})
+	}
+	if got := client.RateLimitStats(HostService2).Rejected; got != 1 {
+		t.Errorf("RateLimitStats().Rejected = %v, want 1", got)
+	}
+}
+
+func TestClient_rateLimitConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(HostSearch, RateLimit{Rate: 1000, Burst: 5}))
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.fetch(context.Background(), HostSearch, server.URL, requestHeader{}); err != nil {
+				t.Errorf("fetch() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := client.RateLimitStats(HostSearch).Requests; got != 20 {
+		t.Errorf("RateLimitStats().Requests = %v, want 20", got)
+	}
+}
+
+func TestClient_rateLimitCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(HostSearch, RateLimit{Rate: 10}))
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+	client.sleep = func(context.Context, time.Duration) error {
+		return context.Canceled
+	}
+
+	if _, err := client.fetch(context.Background(), HostSearch, server.URL, requestHeader{}); err != nil {
+		t.Fatalf("fetch() error = %v", err)
+	}
+	if _, err := client.fetch(context.Background(), HostSearch, server.URL, requestHeader{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("fetch() error = %v, want %v", err, context.Canceled)
+	}
+
+	want := RateLimitStats{Requests: 1}
+	if got := client.RateLimitStats(HostSearch); got != want {
+		t.Errorf("RateLimitStats() = %+v, want %+v", got, want)
+	}
+	// the canceled request gave its token back
+	if wait := client.limiters[HostSearch].reserve(now.Add(100 * time.Millisecond)); wait != 0 {
+		t.Errorf("reserve() wait = %v, want 0", wait)
+	}
+}
@@ -0,0 +1,95 @@
+package dgkala
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/buger/jsonparser"
+)
+
+// RawJSON is the raw JSON of an API object.
+// It's only kept if the client is created using WithRawJSON.
+// Like json.RawMessage, it's encoded to and decoded from JSON unchanged.
+type RawJSON []byte
+
+// MarshalJSON returns the raw JSON, or null if it's empty
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	if len(r) == 0 {
+		return []byte("null"), nil
+	}
+	return r, nil
+}
+
+// UnmarshalJSON sets the raw JSON to a copy of data
+func (r *RawJSON) UnmarshalJSON(data []byte) error {
+	if r == nil {
+		return errors.New("dgkala: RawJSON: UnmarshalJSON on nil pointer")
+	}
+	*r = newRawJSON(data)
+	return nil
+}
+
+// WithRawJSON makes the client keep the raw JSON of offers, products and search results
+// in their Raw fields, so fields which are not modelled yet can be read.
+func WithRawJSON(keep bool) Option {
+	return func(c *Client) {
+		c.rawJSON = keep
+	}
+}
+
+// newRawJSON copies a value into a RawJSON, so it doesn't keep the whole response body alive
+func newRawJSON(value []byte) RawJSON {
+	return append(RawJSON(nil), value...)
+}
+
+// Get returns the raw value at a path of object keys.
+// Strings are returned without quotes but still escaped.
+func (r RawJSON) Get(path ...string) ([]byte, error) {
+	value, _, err := r.lookup(path)
+	return value, err
+}
+
+// GetString returns the string at a path of keys
+func (r RawJSON) GetString(path ...string) (string, error) {
+	return getRawJSON(r, path, jsonparser.String, jsonparser.ParseString)
+}
+
+// GetInt returns the integer at a path of keys
+func (r RawJSON) GetInt(path ...string) (int64, error) {
+	return getRawJSON(r, path, jsonparser.Number, jsonparser.ParseInt)
+}
+
+// GetFloat returns the number at a path of keys
+func (r RawJSON) GetFloat(path ...string) (float64, error) {
+	return getRawJSON(r, path, jsonparser.Number, jsonparser.ParseFloat)
+}
+
+// GetBool returns the boolean at a path of keys
+func (r RawJSON) GetBool(path ...string) (bool, error) {
+	return getRawJSON(r, path, jsonparser.Boolean, jsonparser.ParseBoolean)
+}
+
+// lookup returns the value at a path, failing with ErrMissingField for missing and null values
+func (r RawJSON) lookup(path []string) ([]byte, jsonparser.ValueType, error) {
+	value, dataType, _, err := jsonparser.Get(r, path...)
+	if err == jsonparser.KeyPathNotFoundError || (err == nil && dataType == jsonparser.Null) {
+		return nil, dataType, fmt.Errorf("%w: %v", ErrMissingField, path)
+	}
+	return value, dataType, err
+}
+
+func getRawJSON[T any](r RawJSON, path []string, want jsonparser.ValueType, parse func([]byte) (T, error)) (T, error) {
+	var zero T
+	value, dataType, err := r.lookup(path)
+	if err != nil {
+		return zero, err
+	}
+	if dataType != want {
+		return zero, fmt.Errorf("%w: %v: got %s, want %s", ErrFieldType, path, dataType, want)
+	}
+	parsed, err := parse(value)
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v: %v", ErrFieldType, path, err)
+	}
+	return parsed, nil
+}
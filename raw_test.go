@@ -0,0 +1,66 @@
+package dgkala
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestRawJSON(t *testing.T) {
+	raw := RawJSON(`{"Brand":{"Title":"Samsung","Id":12,"Score":4.5,"Official":true},"Tags":["a","b"],"Empty":null}`)
+	tests := []struct {
+		name    string
+		get     func() (interface{}, error)
+		want    interface{}
+		wantErr error
+	}{
+		{"Test should get strings", func() (interface{}, error) { return raw.GetString("Brand", "Title") }, "Samsung", nil},
+		{"Test should get integers", func() (interface{}, error) { return raw.GetInt("Brand", "Id") }, int64(12), nil},
+		{"Test should get floats", func() (interface{}, error) { return raw.GetFloat("Brand", "Score") }, 4.5, nil},
+		{"Test should get booleans", func() (interface{}, error) { return raw.GetBool("Brand", "Official") }, true, nil},
+		{"Test should get arrays", func() (interface{}, error) {
+			value, err := raw.Get("Tags")
+			return string(value), err
+		}, `["a","b"]`, nil},
+		{"Test should get raw values", func() (interface{}, error) {
+			value, err := raw.Get("Brand", "Id")
+			return string(value), err
+		}, "12", nil},
+		{"Test should report missing fields", func() (interface{}, error) { return raw.GetString("Brand", "Missing") }, "", ErrMissingField},
+		{"Test should report null fields", func() (interface{}, error) { return raw.GetString("Empty") }, "", ErrMissingField},
+		{"Test should report mistyped fields", func() (interface{}, error) { return raw.GetInt("Brand", "Title") }, int64(0), ErrFieldType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawJSON_JSON(t *testing.T) {
+	type fixture struct {
+		Raw   RawJSON
+		Empty RawJSON
+	}
+	encoded, err := json.Marshal(fixture{Raw: RawJSON(`{"a":1}`)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"Raw":{"a":1},"Empty":null}`; string(encoded) != want {
+		t.Errorf("Marshal() = %s, want %s", encoded, want)
+	}
+
+	var decoded fixture
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if string(decoded.Raw) != `{"a":1}` {
+		t.Errorf("Unmarshal() Raw = %s, want %s", decoded.Raw, `{"a":1}`)
+	}
+}
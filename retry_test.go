@@ -0,0 +1,224 @@
+package dgkala
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestClient_retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       RetryPolicy
+		statuses     []int
+		retryAfter   string
+		wantAttempts int
+		wantDelays   []time.Duration
+		wantErr      bool
+	}{
+		{
+			name:         "Test should not retry without a policy",
+			statuses:     []int{503, 200},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "Test should retry 5xx responses with exponential backoff",
+			policy:       RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond},
+			statuses:     []int{500, 502, 503, 200},
+			wantAttempts: 4,
+			wantDelays:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+		},
+		{
+			name:         "Test should cap delays and apply jitter",
+			policy:       RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 150 * time.Millisecond, Jitter: 0.5},
+			statuses:     []int{500, 500, 200},
+			wantAttempts: 3,
+			wantDelays:   []time.Duration{75 * time.Millisecond, 112500 * time.Microsecond},
+		},
+		{
+			name:         "Test should respect Retry-After",
+			policy:       RetryPolicy{MaxAttempts: 2, BaseDelay: 100 * time.Millisecond},
+			statuses:     []int{429, 200},
+			retryAfter:   "3",
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{3 * time.Second},
+		},
+		{
+			name:         "Test should cap Retry-After to MaxDelay",
+			policy:       RetryPolicy{MaxAttempts: 2, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+			statuses:     []int{429, 200},
+			retryAfter:   "86400",
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{time.Second},
+		},
+		{
+			name:         "Test should give up after max attempts",
+			policy:       RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+			statuses:     []int{500, 500, 200},
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{time.Millisecond},
+			wantErr:      true,
+		},
+		{
+			name:         "Test should not retry client errors",
+			policy:       RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			statuses:     []int{400, 200},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name: "Test should use custom RetryOn",
+			policy: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryOn: func(err error) bool {
+				var httpErr *HTTPError
+				return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusBadRequest
+			}},
+			statuses:     []int{400, 200},
+			wantAttempts: 2,
+			wantDelays:   []time.Duration{time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[attempts]
+				attempts++
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			var delays []time.Duration
+			client := NewClient(WithRetryPolicy(tt.policy))
+			client.random = func() float64 { return 0.5 }
+			client.sleep = func(_ context.Context, duration time.Duration) error {
+				delays = append(delays, duration)
+				return nil
+			}
+
+			_, err := client.fetch(context.Background(), HostService2, server.URL, requestHeader{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("fetch() attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+			if !reflect.DeepEqual(delays, tt.wantDelays) {
+				t.Errorf("fetch() delays = %v, want %v", delays, tt.wantDelays)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://search.digikala.com", Err: err}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"Test should retry 503 responses", &HTTPError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"Test should retry 429 responses", &HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{"Test should not retry 501 responses", &HTTPError{StatusCode: http.StatusNotImplemented}, false},
+		{"Test should not retry 404 responses", &HTTPError{StatusCode: http.StatusNotFound}, false},
+		{"Test should retry failed dials", wrap(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}), true},
+		{"Test should retry reset connections", wrap(&net.OpError{Op: "write", Net: "tcp", Err: syscall.ECONNRESET}), true},
+		{"Test should retry unexpected EOFs", wrap(io.ErrUnexpectedEOF), true},
+		{"Test should not retry unknown hosts", wrap(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}), false},
+		{"Test should not retry unsupported schemes", wrap(errors.New(`unsupported protocol scheme "ftp"`)), false},
+		{"Test should not retry canceled requests", wrap(context.Canceled), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_retryPermanentErrors(t *testing.T) {
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsServer.Close()
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closed.Close()
+
+	tests := []struct {
+		name         string
+		address      string
+		wantAttempts int
+	}{
+		{"Test should not retry untrusted certificates", tlsServer.URL, 1},
+		{"Test should not retry unsupported schemes", "ftp://search.digikala.com/api/search", 1},
+		{"Test should retry refused connections", closed.URL, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			client := NewClient(WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+			client.sleep = func(context.Context, time.Duration) error {
+				attempts++
+				return nil
+			}
+			_, err := client.fetch(context.Background(), HostSearch, tt.address, requestHeader{})
+			if err == nil {
+				t.Fatalf("fetch() error = nil, want an error")
+			}
+			if attempts+1 != tt.wantAttempts {
+				t.Errorf("fetch() attempts = %v, want %v: %v", attempts+1, tt.wantAttempts, err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second}
+	previous := time.Duration(0)
+	for attempt := 1; attempt <= 100; attempt++ {
+		delay := policy.delay(attempt, nil, nil)
+		if delay < previous {
+			t.Fatalf("delay(%d) = %v, want at least %v", attempt, delay, previous)
+		}
+		previous = delay
+	}
+	if previous != math.MaxInt64 {
+		t.Errorf("delay(100) = %v, want %v", previous, time.Duration(math.MaxInt64))
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"Test should parse seconds", "120", 2 * time.Minute},
+		{"Test should parse HTTP dates", "Mon, 01 May 2017 12:00:30 GMT", 30 * time.Second},
+		{"Test should ignore past dates", "Mon, 01 May 2017 11:00:00 GMT", 0},
+		{"Test should ignore invalid values", "soon", 0},
+		{"Test should ignore empty values", "", 0},
+		{"Test should cap huge values", "99999999999999", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package dgkala
+
+import (
+	"context"
+	"iter"
+
+	"github.com/mamal72/dgkala/normalize"
+)
+
+// WithKeywordNormalization normalizes search keywords and autocomplete prefixes using normalize.String
+// before sending them, so Arabic letters, Persian digits and ZWNJ variants find the same products
+func WithKeywordNormalization(enabled bool) Option {
+	return func(c *Client) {
+		c.normalizeKeywords = enabled
+	}
+}
+
+// normalizeKeyword normalizes a keyword if the client is configured to
+func (c *Client) normalizeKeyword(keyword string) string {
+	if !c.normalizeKeywords {
+		return keyword
+	}
+	return normalize.String(keyword)
+}
+
+// DefaultSearchSize is the number of search results in each page if SearchOptions.Size is zero
+const DefaultSearchSize = 10
+
+// SearchOptions configures the page of search results to get
+type SearchOptions struct {
+	// From is the offset of the first result
+	From int
+	// Page is the page number, starting from 1. It takes precedence over From if it's set.
+	Page int
+	// Size is the number of results in each page. Zero means DefaultSearchSize.
+	Size int
+	// Limit is the maximum number of results yielded by SearchAll. Zero means all the results.
+	Limit int
+}
+
+// size returns the number of results in each page
+func (o SearchOptions) size() int {
+	if o.Size <= 0 {
+		return DefaultSearchSize
+	}
+	return o.Size
+}
+
+// from returns the offset of the first result of the page
+func (o SearchOptions) from() int {
+	if o.Page > 0 {
+		return (o.Page - 1) * o.size()
+	}
+	return o.From
+}
+
+// SearchAll returns an iterator over all the search results of a keyword.
+// It starts from the page set in options and requests the next pages as needed, up to options.Limit results.
+// Iteration stops after the first error.
+func (c *Client) SearchAll(ctx context.Context, keyword string, options SearchOptions) iter.Seq2[ProductSearchResult, error] {
+	return c.SearchQueryAll(ctx, NewSearchQuery(keyword), options)
+}
+
+// SearchQueryAll is like SearchAll but searches for products matching a query
+func (c *Client) SearchQueryAll(ctx context.Context, query *SearchQuery, options SearchOptions) iter.Seq2[ProductSearchResult, error] {
+	return func(yield func(ProductSearchResult, error) bool) {
+		page := SearchOptions{From: options.from(), Size: options.size()}
+		yielded := 0
+		for {
+			result, err := c.SearchQuery(ctx, query, page)
+			if err != nil {
+				yield(ProductSearchResult{}, err)
+				return
+			}
+			for _, product := range result.Results {
+				if options.Limit > 0 && yielded >= options.Limit {
+					return
+				}
+				if !yield(product, nil) {
+					return
+				}
+				yielded++
+			}
+
+			// advance by the raw hits, as lenient decoding may skip some of them
+			page.From += result.hits
+			if result.hits == 0 || int64(page.From) >= result.Count ||
+				(options.Limit > 0 && yielded >= options.Limit) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package dgkala_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mamal72/dgkala"
+	"github.com/mamal72/dgkala/dgkalatest"
+)
+
+func newSearchResults(count int) []dgkala.ProductSearchResult {
+	results := make([]dgkala.ProductSearchResult, count)
+	for i := range results {
+		results[i] = dgkala.ProductSearchResult{ID: dgkala.ProductID(i + 1), EnglishTitle: "Phone"}
+	}
+	return results
+}
+
+func TestClient_SearchWithOptions(t *testing.T) {
+	server := dgkalatest.NewServer(dgkalatest.WithSearchResults(newSearchResults(25)...))
+	defer server.Close()
+	client := server.Client()
+
+	tests := []struct {
+		name    string
+		options dgkala.SearchOptions
+		wantIDs []dgkala.ProductID
+	}{
+		{"Test should return the first page by default", dgkala.SearchOptions{Size: 3}, []dgkala.ProductID{1, 2, 3}},
+		{"Test should start from the given offset", dgkala.SearchOptions{From: 10, Size: 2}, []dgkala.ProductID{11, 12}},
+		{"Test should return the given page", dgkala.SearchOptions{Page: 3, Size: 4}, []dgkala.ProductID{9, 10, 11, 12}},
+		{"Test should use the default size for pages without size", dgkala.SearchOptions{Page: 2}, []dgkala.ProductID{11, 12, 13, 14, 15, 16, 17, 18, 19, 20}},
+		{"Test should return the last partial page", dgkala.SearchOptions{Page: 3, Size: 10}, []dgkala.ProductID{21, 22, 23, 24, 25}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.SearchWithOptions(context.Background(), "phone", tt.options)
+			if err != nil {
+				t.Fatalf("SearchWithOptions() error = %v", err)
+			}
+			if got.Count != 25 {
+				t.Errorf("SearchWithOptions().Count = %v, want 25", got.Count)
+			}
+			var IDs []dgkala.ProductID
+			for _, result := range got.Results {
+				IDs = append(IDs, result.ID)
+			}
+			if !reflect.DeepEqual(IDs, tt.wantIDs) {
+				t.Errorf("SearchWithOptions() IDs = %v, want %v", IDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestClient_SearchAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   dgkala.SearchOptions
+		wantCount int
+		wantFirst dgkala.ProductID
+	}{
+		{"Test should walk every page", dgkala.SearchOptions{Size: 4}, 25, 1},
+		{"Test should walk pages of the API default size", dgkala.SearchOptions{}, 25, 1},
+		{"Test should stop at the limit", dgkala.SearchOptions{Size: 4, Limit: 10}, 10, 1},
+		{"Test should start from the given page", dgkala.SearchOptions{Page: 2, Size: 10}, 15, 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := dgkalatest.NewServer(dgkalatest.WithSearchResults(newSearchResults(25)...))
+			defer server.Close()
+
+			var IDs []dgkala.ProductID
+			for result, err := range server.Client().SearchAll(context.Background(), "phone", tt.options) {
+				if err != nil {
+					t.Fatalf("SearchAll() error = %v", err)
+				}
+				IDs = append(IDs, result.ID)
+			}
+			if len(IDs) != tt.wantCount {
+				t.Fatalf("SearchAll() yielded %v results, want %v", len(IDs), tt.wantCount)
+			}
+			for i, ID := range IDs {
+				if ID != tt.wantFirst+dgkala.ProductID(i) {
+					t.Fatalf("SearchAll() result %v ID = %v, want %v", i, ID, tt.wantFirst+dgkala.ProductID(i))
+				}
+			}
+		})
+	}
+}
+
+func TestClient_SearchAllError(t *testing.T) {
+	server := dgkalatest.NewServer(dgkalatest.WithError(dgkala.EndpointSearch, http.StatusInternalServerError))
+	defer server.Close()
+
+	errs := 0
+	for _, err := range server.Client().SearchAll(context.Background(), "phone", dgkala.SearchOptions{}) {
+		if err == nil {
+			t.Fatalf("SearchAll() yielded a result, want an error")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("SearchAll() yielded %v errors, want 1", errs)
+	}
+}
+
+func TestClient_SearchAllSkippedHits(t *testing.T) {
+	// the first page has a hit without _source, which lenient decoding skips
+	pages := map[string]string{
+		"":  `{"took":1,"hits":{"total":4,"hits":[{"_id":"broken"},{"_source":{"Id":1}}]}}`,
+		"2": `{"took":1,"hits":{"total":4,"hits":[{"_source":{"Id":2}},{"_source":{"Id":3}}]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("from")]
+		if !ok {
+			http.Error(w, "unexpected page", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := dgkala.NewClient(dgkala.WithSearchBaseURL(server.URL))
+	var IDs []dgkala.ProductID
+	for result, err := range client.SearchAll(context.Background(), "phone", dgkala.SearchOptions{Size: 2}) {
+		if err != nil {
+			t.Fatalf("SearchAll() error = %v", err)
+		}
+		IDs = append(IDs, result.ID)
+	}
+	if want := []dgkala.ProductID{1, 2, 3}; !reflect.DeepEqual(IDs, want) {
+		t.Errorf("SearchAll() IDs = %v, want %v", IDs, want)
+	}
+}
+
+func TestClient_SearchQuery(t *testing.T) {
+	results := []dgkala.ProductSearchResult{
+		{ID: 1, EnglishTitle: "Phone", MinimumPrice: dgkala.Rials(3000), ExistsStatus: dgkala.Available, Colors: []dgkala.ProductColor{{Code: "black"}}},
+		{ID: 2, EnglishTitle: "Phone", MinimumPrice: dgkala.Rials(1000), ExistsStatus: dgkala.Available, HasVideo: true},
+		{ID: 3, EnglishTitle: "Phone", MinimumPrice: dgkala.Rials(2000), ExistsStatus: dgkala.OutOfStock, Colors: []dgkala.ProductColor{{Code: "black"}}},
+		{ID: 4, EnglishTitle: "Phone", MinimumPrice: dgkala.Rials(9000), ExistsStatus: dgkala.Available, Views: 10},
+	}
+	server := dgkalatest.NewServer(dgkalatest.WithSearchResults(results...))
+	defer server.Close()
+	client := server.Client()
+
+	tests := []struct {
+		name    string
+		query   *dgkala.SearchQuery
+		wantIDs []dgkala.ProductID
+	}{
+		{"Test should sort by price", dgkala.NewSearchQuery("phone").SortBy(dgkala.SortPriceAscending), []dgkala.ProductID{2, 3, 1, 4}},
+		{"Test should filter by price range", dgkala.NewSearchQuery("phone").PriceRange(dgkala.Rials(1500), dgkala.Rials(5000)), []dgkala.ProductID{1, 3}},
+		{"Test should filter available products", dgkala.NewSearchQuery("phone").OnlyAvailable().SortBy(dgkala.SortMostViewed), []dgkala.ProductID{4, 1, 2}},
+		{"Test should filter by color", dgkala.NewSearchQuery("phone").Colors("black").OnlyAvailable(), []dgkala.ProductID{1}},
+		{"Test should filter products with video", dgkala.NewSearchQuery("phone").HasVideo(), []dgkala.ProductID{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.SearchQuery(context.Background(), tt.query, dgkala.SearchOptions{})
+			if err != nil {
+				t.Fatalf("SearchQuery() error = %v", err)
+			}
+			var IDs []dgkala.ProductID
+			for _, result := range got.Results {
+				IDs = append(IDs, result.ID)
+			}
+			if !reflect.DeepEqual(IDs, tt.wantIDs) {
+				t.Errorf("SearchQuery() IDs = %v, want %v", IDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestClient_SearchFacets(t *testing.T) {
+	facets := dgkala.SearchFacets{
+		Brands:       []dgkala.FacetBucket{{Key: "Samsung", Count: 2}},
+		Colors:       []dgkala.FacetBucket{{Key: "black", Count: 1}},
+		PriceRanges:  []dgkala.PriceBucket{{From: dgkala.Rials(0), To: dgkala.Rials(1000000), Count: 2}},
+		Availability: []dgkala.AvailabilityBucket{{Status: dgkala.Available, Count: 2}},
+	}
+	tests := []struct {
+		name      string
+		options   []dgkalatest.Option
+		wantLocal bool
+	}{
+		{"Test should return the API facets", []dgkalatest.Option{dgkalatest.WithSearchFacets(facets)}, false},
+		{"Test should compute facets locally without aggregations", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]dgkalatest.Option{dgkalatest.WithSearchResults(newSearchResults(2)...)}, tt.options...)
+			server := dgkalatest.NewServer(options...)
+			defer server.Close()
+
+			got, err := server.Client().Search(context.Background(), "phone")
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if got.Facets.Local != tt.wantLocal {
+				t.Errorf("Search().Facets.Local = %v, want %v", got.Facets.Local, tt.wantLocal)
+			}
+			if !tt.wantLocal && !reflect.DeepEqual(got.Facets, facets) {
+				t.Errorf("Search().Facets = %+v, want %+v", got.Facets, facets)
+			}
+		})
+	}
+}
+
+func TestClient_Categories(t *testing.T) {
+	tree := []dgkala.Category{
+		{ID: 1, PersianTitle: "کالای دیجیتال", EnglishTitle: "Digital", URLCode: "digital", Children: []dgkala.Category{
+			{ID: 2, ParentID: 1, PersianTitle: "موبایل", EnglishTitle: "Mobile", URLCode: "mobile", Children: []dgkala.Category{}},
+		}},
+		{ID: 3, PersianTitle: "کتاب", EnglishTitle: "Books", URLCode: "books", Children: []dgkala.Category{}},
+	}
+	server := dgkalatest.NewServer(
+		dgkalatest.WithCategories(tree...),
+		dgkalatest.WithSearchResults(dgkala.ProductSearchResult{ID: 4}),
+		dgkalatest.WithCategoryProducts(2, newSearchResults(3)...),
+		dgkalatest.WithCategoryProducts(3, dgkala.ProductSearchResult{ID: 5}),
+	)
+	defer server.Close()
+	client := server.Client()
+
+	got, err := client.Categories(context.Background())
+	if err != nil {
+		t.Fatalf("Categories() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, tree) {
+		t.Errorf("Categories() = %+v, want %+v", got, tree)
+	}
+
+	var IDs []dgkala.ProductID
+	for product, err := range client.CategoryProductsAll(context.Background(), 2, dgkala.SearchOptions{Size: 2}) {
+		if err != nil {
+			t.Fatalf("CategoryProductsAll() error = %v", err)
+		}
+		IDs = append(IDs, product.ID)
+	}
+	if want := []dgkala.ProductID{1, 2, 3}; !reflect.DeepEqual(IDs, want) {
+		t.Errorf("CategoryProductsAll() IDs = %v, want %v", IDs, want)
+	}
+
+	page, err := client.CategoryProducts(context.Background(), 3, dgkala.SearchOptions{})
+	if err != nil {
+		t.Fatalf("CategoryProducts() error = %v", err)
+	}
+	if page.Count != 1 || page.Results[0].ID != 5 {
+		t.Errorf("CategoryProducts() = %+v, want product 5", page.Results)
+	}
+}
+
+func TestClient_SearchKeywordNormalization(t *testing.T) {
+	server := dgkalatest.NewServer(dgkalatest.WithSearchResults(
+		dgkala.ProductSearchResult{ID: 1, PersianTitle: "کیف لپ\u200cتاپ مشکی"},
+	))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		options   []dgkala.Option
+		wantCount int64
+	}{
+		{"Test should send keywords untouched by default", nil, 0},
+		{"Test should normalize keywords", []dgkala.Option{dgkala.WithKeywordNormalization(true)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := server.Client(tt.options...).Search(context.Background(), " كيف  لپ\u200c\u200cتاپ ")
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if got.Count != tt.wantCount {
+				t.Errorf("Search().Count = %v, want %v", got.Count, tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package dgkala
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// SearchSort is the sort order of search results
+type SearchSort int
+
+const (
+	// SortRelevance sorts results by relevance to the keyword
+	SortRelevance SearchSort = iota
+	// SortPriceAscending sorts results by minimum price, cheapest first
+	SortPriceAscending
+	// SortPriceDescending sorts results by minimum price, most expensive first
+	SortPriceDescending
+	// SortNewest sorts results by registration date, newest first
+	SortNewest
+	// SortMostViewed sorts results by views, most viewed first
+	SortMostViewed
+	// SortBestSelling sorts results by last period sales, best selling first
+	SortBestSelling
+)
+
+// sortParameters are the search API sort parameter values of each sort order
+var sortParameters = map[SearchSort]string{
+	SortPriceAscending:  "MinPrice:asc",
+	SortPriceDescending: "MinPrice:desc",
+	SortNewest:          "RegDateTime:desc",
+	SortMostViewed:      "ViewCounter:desc",
+	SortBestSelling:     "LastPeriodSaleCounter:desc",
+}
+
+// SearchQuery is a search keyword with filters and a sort order.
+// Its methods return the query itself so they can be chained:
+//
+//	query := dgkala.NewSearchQuery("phone").PriceRange(dgkala.Tomans(100000), dgkala.Tomans(500000)).OnlyAvailable().SortBy(dgkala.SortPriceAscending)
+type SearchQuery struct {
+	keyword       string
+	minPrice      Money
+	maxPrice      Money
+	onlyAvailable bool
+	colors        []string
+	brandID       BrandID
+	categoryID    CategoryID
+	hasVideo      bool
+	hasGift       bool
+	sort          SearchSort
+}
+
+// NewSearchQuery returns a SearchQuery for a keyword
+func NewSearchQuery(keyword string) *SearchQuery {
+	return &SearchQuery{keyword: keyword}
+}
+
+// PriceRange filters products with a minimum price between min and max. Zero values mean no bound.
+func (q *SearchQuery) PriceRange(min, max Money) *SearchQuery {
+	q.minPrice, q.maxPrice = min, max
+	return q
+}
+
+// OnlyAvailable filters products which are Available to buy
+func (q *SearchQuery) OnlyAvailable() *SearchQuery {
+	q.onlyAvailable = true
+	return q
+}
+
+// Colors filters products having any of the colors, matching ProductColor.Code
+func (q *SearchQuery) Colors(codes ...string) *SearchQuery {
+	q.colors = append(q.colors, codes...)
+	return q
+}
+
+// Brand filters products of a brand
+func (q *SearchQuery) Brand(brandID BrandID) *SearchQuery {
+	q.brandID = brandID
+	return q
+}
+
+// Category filters products of a category
+func (q *SearchQuery) Category(categoryID CategoryID) *SearchQuery {
+	q.categoryID = categoryID
+	return q
+}
+
+// HasVideo filters products having a video
+func (q *SearchQuery) HasVideo() *SearchQuery {
+	q.hasVideo = true
+	return q
+}
+
+// HasGift filters products having a gift
+func (q *SearchQuery) HasGift() *SearchQuery {
+	q.hasGift = true
+	return q
+}
+
+// SortBy sets the sort order of the results
+func (q *SearchQuery) SortBy(sort SearchSort) *SearchQuery {
+	q.sort = sort
+	return q
+}
+
+// values encodes the query into search API parameters
+func (q *SearchQuery) values() url.Values {
+	values := url.Values{}
+	values.Set("keyword", q.keyword)
+	if minPrice, err := q.minPrice.ToRial(); err == nil && minPrice.Amount > 0 {
+		values.Set("minprice", strconv.FormatInt(minPrice.Amount, 10))
+	}
+	if maxPrice, err := q.maxPrice.ToRial(); err == nil && maxPrice.Amount > 0 {
+		values.Set("maxprice", strconv.FormatInt(maxPrice.Amount, 10))
+	}
+	if q.onlyAvailable {
+		values.Set("status", strconv.Itoa(int(Available)))
+	}
+	for _, color := range q.colors {
+		values.Add("color", color)
+	}
+	if q.brandID > 0 {
+		values.Set("brand", q.brandID.String())
+	}
+	if q.categoryID > 0 {
+		values.Set("category", q.categoryID.String())
+	}
+	if q.hasVideo {
+		values.Set("hasvideo", "true")
+	}
+	if q.hasGift {
+		values.Set("hasgift", "true")
+	}
+	if sort, ok := sortParameters[q.sort]; ok {
+		values.Set("sort", sort)
+	}
+	return values
+}
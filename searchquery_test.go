@@ -0,0 +1,57 @@
+package dgkala
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSearchQuery_values(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *SearchQuery
+		want  url.Values
+	}{
+		{
+			name:  "Test should encode a bare keyword",
+			query: NewSearchQuery("phone"),
+			want:  url.Values{"keyword": {"phone"}},
+		},
+		{
+			name: "Test should encode filters and sort order",
+			query: NewSearchQuery("phone").
+				PriceRange(Rials(1000), Tomans(500)).
+				OnlyAvailable().
+				Colors("black", "white").
+				Brand(12).
+				Category(34).
+				HasVideo().
+				HasGift().
+				SortBy(SortBestSelling),
+			want: url.Values{
+				"keyword":  {"phone"},
+				"minprice": {"1000"},
+				"maxprice": {"5000"},
+				"status":   {"2"},
+				"color":    {"black", "white"},
+				"brand":    {"12"},
+				"category": {"34"},
+				"hasvideo": {"true"},
+				"hasgift":  {"true"},
+				"sort":     {"LastPeriodSaleCounter:desc"},
+			},
+		},
+		{
+			name:  "Test should encode open price ranges",
+			query: NewSearchQuery("").PriceRange(Money{}, Rials(5000)).SortBy(SortNewest),
+			want:  url.Values{"keyword": {""}, "maxprice": {"5000"}, "sort": {"RegDateTime:desc"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchQuery.values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package dgkala
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// service2Response is the envelope of service2 API responses
+type service2Response struct {
+	Data   json.RawMessage
+	Status string
+}
+
+// decodeService2Response decodes the Data of a service2 API response into data.
+// Null Data is reported as ErrNotFound.
+func decodeService2Response(body []byte, apiAddress string, data interface{}) error {
+	var response service2Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		return &DecodeError{URL: apiAddress, Err: err}
+	}
+	if !isStatusOK(response.Status) {
+		return &APIError{Status: response.Status, URL: apiAddress}
+	}
+	if len(response.Data) == 0 || string(response.Data) == "null" {
+		return fmt.Errorf("dgkala: GET %s: %w", apiAddress, ErrNotFound)
+	}
+	if err := json.Unmarshal(response.Data, data); err != nil {
+		return &DecodeError{URL: apiAddress, Err: err}
+	}
+	return nil
+}
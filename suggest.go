@@ -0,0 +1,99 @@
+package dgkala
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SuggestedProduct is a product suggested for a search prefix
+type SuggestedProduct struct {
+	ID           ProductID `json:"Id"`
+	EnglishTitle string    `json:"EnTitle"`
+	PersianTitle string    `json:"FaTitle"`
+	// Image is the full address of the product image, ImagePath in the API
+	Image string `json:"ImagePath"`
+}
+
+// Suggestions are the autocomplete suggestions for a search prefix
+type Suggestions struct {
+	Keywords   []string
+	Categories []Category
+	Products   []SuggestedProduct
+}
+
+// clone returns a copy of the suggestions which doesn't share their slices,
+// so callers can't modify the suggestions of the prefix cache
+func (s Suggestions) clone() Suggestions {
+	return Suggestions{
+		Keywords:   slices.Clone(s.Keywords),
+		Categories: cloneCategories(s.Categories),
+		Products:   slices.Clone(s.Products),
+	}
+}
+
+// cloneCategories returns a deep copy of categories with their children
+func cloneCategories(categories []Category) []Category {
+	cloned := slices.Clone(categories)
+	for i := range cloned {
+		cloned[i].Children = cloneCategories(cloned[i].Children)
+	}
+	return cloned
+}
+
+// WithSuggestCache keeps the suggestions of up to size prefixes in memory for ttl,
+// so repeated keystrokes don't wait for the API
+func WithSuggestCache(size int, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.suggestCacheSize, c.suggestCacheTTL = size, ttl
+	}
+}
+
+func (c *Client) getSuggestAPIAddress(prefix string) string {
+	query := url.Values{}
+	query.Set("keyword", prefix)
+	return c.searchBaseURL + fmt.Sprintf(suggestAPIPath, query.Encode())
+}
+
+// Suggest returns the suggested keywords, categories and products for a search prefix
+func (c *Client) Suggest(ctx context.Context, prefix string) (Suggestions, error) {
+	prefix = strings.TrimSpace(c.normalizeKeyword(prefix))
+	if prefix == "" {
+		return Suggestions{Keywords: []string{}, Categories: []Category{}, Products: []SuggestedProduct{}}, nil
+	}
+	if c.suggestCache != nil {
+		if suggestions, ok := c.suggestCache.get(prefix); ok {
+			return suggestions.clone(), nil
+		}
+	}
+
+	apiAddress := c.getSuggestAPIAddress(prefix)
+	return coalesce(c, ctx, EndpointSuggest, apiAddress, func(ctx context.Context) (Suggestions, error) {
+		return c.suggest(ctx, prefix, apiAddress)
+	})
+}
+
+func (c *Client) suggest(ctx context.Context, prefix, apiAddress string) (Suggestions, error) {
+	suggestions := Suggestions{Keywords: []string{}, Categories: []Category{}, Products: []SuggestedProduct{}}
+	err := c.get(ctx, EndpointSuggest, apiAddress, requestHeader{}, func(body []byte) error {
+		if err := json.Unmarshal(body, &suggestions); err != nil {
+			return &DecodeError{URL: apiAddress, Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return Suggestions{}, err
+	}
+	for i, product := range suggestions.Products {
+		suggestions.Products[i].Image = c.getStaticResourceAddress(product.Image)
+	}
+
+	if c.suggestCache != nil {
+		c.suggestCache.set(prefix, suggestions.clone(), c.now().Add(c.suggestCacheTTL))
+	}
+	return suggestions, nil
+}
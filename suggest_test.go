@@ -0,0 +1,77 @@
+package dgkala_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mamal72/dgkala"
+	"github.com/mamal72/dgkala/dgkalatest"
+)
+
+func TestClient_Suggest(t *testing.T) {
+	suggestions := dgkala.Suggestions{
+		Keywords:   []string{"گوشی سامسونگ", "گوشی اپل"},
+		Categories: []dgkala.Category{{ID: 2, PersianTitle: "گوشی موبایل", EnglishTitle: "Mobile Phone", URLCode: "mobile-phone"}},
+		Products:   []dgkala.SuggestedProduct{{ID: 6071, EnglishTitle: "Galaxy", PersianTitle: "گلکسی", Image: "galaxy.jpg"}},
+	}
+	server := dgkalatest.NewServer(dgkalatest.WithSuggestions("گوشی", suggestions))
+	defer server.Close()
+
+	want := suggestions
+	want.Products = []dgkala.SuggestedProduct{{ID: 6071, EnglishTitle: "Galaxy", PersianTitle: "گلکسی", Image: server.URL + "/digikala/galaxy.jpg"}}
+	empty := dgkala.Suggestions{Keywords: []string{}, Categories: []dgkala.Category{}, Products: []dgkala.SuggestedProduct{}}
+
+	tests := []struct {
+		name         string
+		prefix       string
+		want         dgkala.Suggestions
+		wantRequests int
+	}{
+		{"Test should return suggestions", "گوشی", want, 1},
+		{"Test should trim prefixes and use the prefix cache", " گوشی ", want, 1},
+		{"Test should return empty suggestions for unknown prefixes", "xyz", empty, 2},
+		{"Test should not send requests for empty prefixes", "  ", empty, 2},
+	}
+	client := server.Client(dgkala.WithSuggestCache(100, time.Minute))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Suggest(context.Background(), tt.prefix)
+			if err != nil {
+				t.Fatalf("Suggest() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest() = %+v, want %+v", got, tt.want)
+			}
+			if requests := server.Requests(dgkala.EndpointSuggest); requests != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestClient_SuggestCacheCopies(t *testing.T) {
+	suggestions := dgkala.Suggestions{
+		Keywords:   []string{"گوشی سامسونگ"},
+		Categories: []dgkala.Category{{ID: 2, EnglishTitle: "Mobile Phone"}},
+		Products:   []dgkala.SuggestedProduct{{ID: 6071, EnglishTitle: "Galaxy"}},
+	}
+	server := dgkalatest.NewServer(dgkalatest.WithSuggestions("گوشی", suggestions))
+	defer server.Close()
+	client := server.Client(dgkala.WithSuggestCache(100, time.Minute))
+
+	for i := 0; i < 3; i++ {
+		got, err := client.Suggest(context.Background(), "گوشی")
+		if err != nil {
+			t.Fatalf("Suggest() error = %v", err)
+		}
+		if got.Keywords[0] != "گوشی سامسونگ" || got.Categories[0].ID != 2 || got.Products[0].ID != 6071 {
+			t.Fatalf("Suggest() %d = %+v, want unmodified suggestions", i, got)
+		}
+		got.Keywords[0], got.Categories[0].ID, got.Products[0].ID = "changed", 0, 0
+	}
+	if requests := server.Requests(dgkala.EndpointSuggest); requests != 1 {
+		t.Errorf("requests = %v, want 1", requests)
+	}
+}